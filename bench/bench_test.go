@@ -4,7 +4,9 @@ import (
 	_ "embed"
 	"encoding/json"
 	"testing"
+	"time"
 
+	"github.com/metafates/schema/parse"
 	"github.com/metafates/schema/required"
 	"github.com/metafates/schema/validate"
 )
@@ -123,3 +125,113 @@ func BenchmarkUnmarshalJSON(b *testing.B) {
 		})
 	})
 }
+
+// Plain is the same as [Data], but without schema types, e.g. like structs generated by protoc.
+type Plain []struct {
+	ID         string  `json:"_id"`
+	Index      int     `json:"index"`
+	GUID       string  `json:"guid"`
+	IsActive   bool    `json:"isActive"`
+	Balance    string  `json:"balance"`
+	Picture    string  `json:"picture"`
+	Age        int     `json:"age"`
+	EyeColor   string  `json:"eyeColor"`
+	Name       string  `json:"name"`
+	Gender     string  `json:"gender"`
+	Company    string  `json:"company"`
+	Email      string  `json:"email"`
+	Phone      string  `json:"phone"`
+	Address    string  `json:"address"`
+	About      string  `json:"about"`
+	Registered string  `json:"registered"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Tags       []string
+	Friends    []struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"friends"`
+	Greeting      string `json:"greeting"`
+	FavoriteFruit string `json:"favoriteFruit"`
+}
+
+func BenchmarkParse(b *testing.B) {
+	var sample Plain
+
+	if err := json.Unmarshal(testdata, &sample); err != nil {
+		b.Fatal(err)
+	}
+
+	// thousands of elements, like a large list of gRPC messages
+	src := make(Plain, 0, 5000)
+
+	for len(src) < cap(src) {
+		src = append(src, sample...)
+	}
+
+	options := []parse.Option{
+		parse.WithFieldTag("json"),
+		parse.WithFieldMatching(parse.MatchCaseInsensitive),
+	}
+
+	b.Run("plain", func(b *testing.B) {
+		for b.Loop() {
+			var data Data
+
+			if err := parse.Parse(src, &data, options...); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		plan := parse.NewPlan(options...)
+
+		for b.Loop() {
+			var data Data
+
+			if err := plan.Parse(src, &data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkMarshalBinary(b *testing.B) {
+	var value required.Any[time.Time]
+
+	if err := value.Parse(time.Now()); err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("binary", func(b *testing.B) {
+		for b.Loop() {
+			data, err := value.MarshalBinary()
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var decoded required.Any[time.Time]
+
+			if err := decoded.UnmarshalBinary(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// previous implementation of MarshalBinary
+	b.Run("gob", func(b *testing.B) {
+		for b.Loop() {
+			data, err := value.GobEncode()
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			var decoded required.Any[time.Time]
+
+			if err := decoded.GobDecode(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
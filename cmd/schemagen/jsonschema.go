@@ -0,0 +1,1095 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/dave/jennifer/jen"
+)
+
+const (
+	requiredPkg = "github.com/metafates/schema/required"
+	optionalPkg = "github.com/metafates/schema/optional"
+)
+
+// jsonSchema is a subset of JSON Schema understood by schemagen.
+type jsonSchema struct {
+	Ref                  string          `json:"$ref"`
+	Defs                 orderedSchemas  `json:"$defs"`
+	Definitions          orderedSchemas  `json:"definitions"`
+	Title                string          `json:"title"`
+	Description          string          `json:"description"`
+	Type                 schemaTypes     `json:"type"`
+	Format               string          `json:"format"`
+	Properties           orderedSchemas  `json:"properties"`
+	Required             []string        `json:"required"`
+	Items                *jsonSchema     `json:"items"`
+	AdditionalProperties *jsonSchema     `json:"additionalProperties"`
+	AllOf                []*jsonSchema   `json:"allOf"`
+	AnyOf                []*jsonSchema   `json:"anyOf"`
+	OneOf                []*jsonSchema   `json:"oneOf"`
+	Enum                 []any           `json:"enum"`
+	Const                json.RawMessage `json:"const"`
+	Pattern              string          `json:"pattern"`
+	MinLength            *int            `json:"minLength"`
+	MaxLength            *int            `json:"maxLength"`
+	MinItems             *int            `json:"minItems"`
+	MaxItems             *int            `json:"maxItems"`
+	UniqueItems          bool            `json:"uniqueItems"`
+	Minimum              *float64        `json:"minimum"`
+	Maximum              *float64        `json:"maximum"`
+	ExclusiveMinimum     json.RawMessage `json:"exclusiveMinimum"`
+	ExclusiveMaximum     json.RawMessage `json:"exclusiveMaximum"`
+	MultipleOf           *float64        `json:"multipleOf"`
+	Nullable             bool            `json:"nullable"`
+
+	// boolean reports that this schema was declared as a boolean (true or false) schema.
+	boolean bool
+}
+
+func (s *jsonSchema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true", "false":
+		*s = jsonSchema{boolean: true}
+
+		return nil
+	}
+
+	type plain jsonSchema
+
+	return json.Unmarshal(data, (*plain)(s))
+}
+
+// isObject reports whether this schema describes an object with known properties.
+func (s *jsonSchema) isObject() bool {
+	return len(s.Properties) > 0 || (s.Type.primary() == "object" && s.AdditionalProperties == nil)
+}
+
+type namedSchema struct {
+	Name   string
+	Schema *jsonSchema
+}
+
+// orderedSchemas is a JSON object of schemas which preserves declaration order.
+type orderedSchemas []namedSchema
+
+func (o *orderedSchemas) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		name, ok := token.(string)
+		if !ok {
+			return fmt.Errorf("unexpected token %v", token)
+		}
+
+		var schema jsonSchema
+
+		if err := dec.Decode(&schema); err != nil {
+			return err
+		}
+
+		*o = append(*o, namedSchema{Name: name, Schema: &schema})
+	}
+
+	return nil
+}
+
+// schemaTypes is a "type" keyword which is either a single type or a list of types.
+type schemaTypes []string
+
+func (t *schemaTypes) UnmarshalJSON(data []byte) error {
+	var single string
+
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = schemaTypes{single}
+
+		return nil
+	}
+
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// primary returns the first non-null type.
+func (t schemaTypes) primary() string {
+	for _, name := range t {
+		if name != "null" {
+			return name
+		}
+	}
+
+	return ""
+}
+
+func (t schemaTypes) nullable() bool {
+	return slices.Contains(t, "null")
+}
+
+// check is a single validator applied to a value.
+type check struct {
+	// qual is the package of validator. Empty for generated validators.
+	qual string
+	name string
+
+	// params are type parameters of the validator.
+	// Validators from the validate package have aliases with the same parameters in required and optional packages.
+	params []jen.Code
+}
+
+// aliased reports whether required and optional packages have an alias for this validator.
+func (c check) aliased() bool {
+	switch c.name {
+	case "And", "Or", "Not":
+		return false
+
+	default:
+		return c.qual == validatePkg
+	}
+}
+
+func (c check) code() jen.Code {
+	if c.qual == "" {
+		return jen.Id(c.name)
+	}
+
+	return jen.Qual(c.qual, c.name).Types(c.params...)
+}
+
+// resolved is a go representation of a schema.
+type resolved struct {
+	base   jen.Code
+	checks []check
+
+	// object reports whether base is a struct type.
+	object bool
+}
+
+type jsonSchemaGenerator struct {
+	root *jsonSchema
+	file *jen.File
+
+	// defs maps json pointers to defined go type names.
+	defs map[string]string
+
+	// defSchemas maps go type names of defs to their schemas.
+	defSchemas map[string]*jsonSchema
+
+	// resolvedDefs caches resolved non-object defs.
+	resolvedDefs map[string]resolved
+
+	// items contains item types of array schemas.
+	items map[*jsonSchema]jen.Code
+
+	// taken contains all declared go names.
+	taken map[string]bool
+
+	// queue contains object schemas waiting to be declared as structs.
+	queue []namedSchema
+
+	// structs contains names of all generated struct types in the declaration order.
+	structs []string
+
+	validators []jen.Code
+}
+
+// genFromJSONSchema generates go types from the JSON Schema at path and writes them into dir.
+// It returns names of generated struct types.
+func genFromJSONSchema(path, dir, rootName string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	var root jsonSchema
+
+	if err := json.Unmarshal(data, &root); err != nil {
+		log.Fatalf("parse %s: %v", path, err)
+	}
+
+	if rootName == "" {
+		rootName = goName(root.Title)
+	}
+
+	if rootName == "" {
+		rootName = goName(strings.TrimSuffix(filepath.Base(path), filepath.Ext(path)))
+	}
+
+	f := jen.NewFile(packageName(dir))
+	f.HeaderComment("Code generated by schemagen; DO NOT EDIT.")
+
+	g := jsonSchemaGenerator{
+		root:         &root,
+		file:         f,
+		defs:         make(map[string]string),
+		defSchemas:   make(map[string]*jsonSchema),
+		resolvedDefs: make(map[string]resolved),
+		items:        make(map[*jsonSchema]jen.Code),
+		taken:        make(map[string]bool),
+	}
+
+	g.gen(rootName)
+
+	outputPath := filepath.Join(dir, rootName+"_jsonschema.go")
+
+	if err := f.Save(outputPath); err != nil {
+		log.Fatalln(err)
+	}
+
+	return g.structs
+}
+
+func (g *jsonSchemaGenerator) gen(rootName string) {
+	var defNames []string
+
+	for _, defs := range []struct {
+		prefix  string
+		schemas orderedSchemas
+	}{
+		{prefix: "#/$defs/", schemas: g.root.Defs},
+		{prefix: "#/definitions/", schemas: g.root.Definitions},
+	} {
+		for _, def := range defs.schemas {
+			name := g.declare(goName(def.Name))
+
+			g.defs[defs.prefix+def.Name] = name
+			g.defSchemas[name] = def.Schema
+
+			defNames = append(defNames, name)
+		}
+	}
+
+	if g.root.isObject() || len(g.root.AllOf) > 0 {
+		g.queue = append(g.queue, namedSchema{Name: g.declare(rootName), Schema: g.root})
+	}
+
+	for _, name := range defNames {
+		schema := g.defSchemas[name]
+
+		if g.isObjectLike(schema) {
+			g.queue = append(g.queue, namedSchema{Name: name, Schema: schema})
+		} else {
+			g.genNamed(name, schema)
+		}
+	}
+
+	for len(g.queue) > 0 {
+		next := g.queue[0]
+		g.queue = g.queue[1:]
+
+		g.genStruct(next.Name, next.Schema)
+	}
+
+	for _, v := range g.validators {
+		g.file.Add(v)
+	}
+}
+
+// declare reserves a unique go name.
+func (g *jsonSchemaGenerator) declare(name string) string {
+	if name == "" {
+		name = "Type"
+	}
+
+	unique := name
+
+	for i := 2; g.taken[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+
+	g.taken[unique] = true
+
+	return unique
+}
+
+func (g *jsonSchemaGenerator) deref(schema *jsonSchema) (*jsonSchema, string) {
+	if schema.Ref == "" {
+		return schema, ""
+	}
+
+	name, ok := g.defs[schema.Ref]
+	if !ok {
+		log.Fatalf("unsupported $ref: %s", schema.Ref)
+	}
+
+	return g.defSchemas[name], name
+}
+
+// isObjectLike reports whether schema must be represented as a struct.
+func (g *jsonSchemaGenerator) isObjectLike(schema *jsonSchema) bool {
+	schema, _ = g.deref(schema)
+
+	if schema.isObject() {
+		return true
+	}
+
+	for _, sub := range schema.AllOf {
+		if g.isObjectLike(sub) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (g *jsonSchemaGenerator) genNamed(name string, schema *jsonSchema) {
+	comment(g.file, name, schema)
+	g.file.Type().Id(name).Add(g.baseType(schema, name))
+	g.file.Line()
+
+	// validators refer to the named type instead of its underlying type
+	base := jen.Id(name)
+
+	g.resolvedDefs[name] = resolved{base: base, checks: g.checks(schema, base, g.kind(schema), name, false)}
+}
+
+func (g *jsonSchemaGenerator) genStruct(name string, schema *jsonSchema) {
+	g.structs = append(g.structs, name)
+
+	var (
+		properties orderedSchemas
+		required   []string
+	)
+
+	var collect func(s *jsonSchema)
+
+	collect = func(s *jsonSchema) {
+		s, _ = g.deref(s)
+
+		for _, p := range s.Properties {
+			i := slices.IndexFunc(properties, func(n namedSchema) bool { return n.Name == p.Name })
+			if i >= 0 {
+				// allOf members may refine the same property
+				properties[i].Schema = &jsonSchema{AllOf: []*jsonSchema{properties[i].Schema, p.Schema}}
+			} else {
+				properties = append(properties, p)
+			}
+		}
+
+		required = append(required, s.Required...)
+
+		for _, sub := range s.AllOf {
+			collect(sub)
+		}
+	}
+
+	collect(schema)
+
+	fields := make([]jen.Code, 0, len(properties))
+
+	for _, p := range properties {
+		fieldName := goName(p.Name)
+
+		r := g.resolve(p.Schema, name+fieldName)
+
+		isRequired := slices.Contains(required, p.Name) && !p.Schema.Nullable && !p.Schema.Type.nullable()
+
+		field := jen.Id(fieldName).Add(fieldType(r, isRequired)).Tag(map[string]string{"json": p.Name})
+
+		if p.Schema.Description != "" {
+			fields = append(fields, jen.Comment(p.Schema.Description))
+		}
+
+		fields = append(fields, field)
+	}
+
+	comment(g.file, name, schema)
+	g.file.Type().Id(name).Struct(fields...)
+	g.file.Line()
+}
+
+func comment(f *jen.File, name string, schema *jsonSchema) {
+	if schema.Description != "" {
+		f.Comment(name + " " + lowerFirst(schema.Description))
+	} else if schema.Title != "" && goName(schema.Title) != name {
+		f.Comment(name + " is " + schema.Title)
+	}
+}
+
+// fieldType returns required or optional field type for the resolved schema.
+func fieldType(r resolved, isRequired bool) jen.Code {
+	pkg := optionalPkg
+	if isRequired {
+		pkg = requiredPkg
+	}
+
+	switch {
+	case len(r.checks) == 0:
+		return jen.Qual(pkg, "Any").Types(r.base)
+
+	case len(r.checks) == 1 && r.checks[0].aliased():
+		return jen.Qual(pkg, r.checks[0].name).Types(r.checks[0].params...)
+
+	default:
+		return jen.Qual(pkg, "Custom").Types(r.base, and(r.base, r.checks).code())
+	}
+}
+
+// and combines checks with [validate.And].
+func and(base jen.Code, checks []check) check {
+	if len(checks) == 1 {
+		return checks[0]
+	}
+
+	return check{
+		qual:   validatePkg,
+		name:   "And",
+		params: []jen.Code{base, checks[0].code(), and(base, checks[1:]).code()},
+	}
+}
+
+// or combines checks with [validate.Or].
+func or(base jen.Code, checks []check) check {
+	if len(checks) == 1 {
+		return checks[0]
+	}
+
+	return check{
+		qual:   validatePkg,
+		name:   "Or",
+		params: []jen.Code{base, checks[0].code(), or(base, checks[1:]).code()},
+	}
+}
+
+// resolve returns go type and validators for the schema.
+// Name is used for declaring nested types and validators.
+func (g *jsonSchemaGenerator) resolve(schema *jsonSchema, name string) resolved {
+	if schema.Ref != "" {
+		_, defName := g.deref(schema)
+
+		if r, ok := g.resolvedDefs[defName]; ok {
+			return r
+		}
+
+		return resolved{base: jen.Id(defName), object: g.isObjectLike(schema)}
+	}
+
+	if g.isObjectLike(schema) {
+		structName := g.declare(name)
+
+		g.queue = append(g.queue, namedSchema{Name: structName, Schema: schema})
+
+		return resolved{base: jen.Id(structName), object: true}
+	}
+
+	if variants := slices.Concat(schema.AnyOf, schema.OneOf); len(variants) > 0 {
+		return g.resolveVariants(schema, variants, name)
+	}
+
+	typed := schema
+
+	// type may be declared by allOf members only
+	for _, sub := range schema.AllOf {
+		if typed.Type.primary() != "" {
+			break
+		}
+
+		typed, _ = g.deref(sub)
+	}
+
+	base := g.baseType(typed, name)
+
+	return resolved{base: base, checks: g.checks(schema, base, g.kind(typed), name, true)}
+}
+
+func (g *jsonSchemaGenerator) resolveVariants(schema *jsonSchema, variants []*jsonSchema, name string) resolved {
+	base := g.baseType(schema, name)
+	kind := g.kind(schema)
+
+	if kind == "" {
+		// take type from the variants, they must agree on it
+		var types []string
+
+		for _, v := range variants {
+			v, _ = g.deref(v)
+
+			if t := v.Type.primary(); t != "" && !slices.Contains(types, t) {
+				types = append(types, t)
+			}
+		}
+
+		if len(types) != 1 {
+			log.Printf("%s: variants of different types are not supported, falling back to any", name)
+
+			return resolved{base: jen.Any()}
+		}
+
+		kind = types[0]
+		base = g.baseType(&jsonSchema{Type: schemaTypes{kind}, Format: variants[0].Format}, name)
+	}
+
+	alternatives := make([]check, 0, len(variants))
+
+	for i, v := range variants {
+		v, _ = g.deref(v)
+
+		checks := g.checks(v, base, kind, name+"Variant"+strconv.Itoa(i+1), true)
+		if len(checks) == 0 {
+			// one of the variants accepts anything
+			alternatives = nil
+
+			break
+		}
+
+		alternatives = append(alternatives, and(base, checks))
+	}
+
+	checks := g.checks(schema, base, kind, name, true)
+
+	if len(alternatives) > 0 {
+		checks = append(checks, or(base, alternatives))
+	}
+
+	return resolved{base: base, checks: checks}
+}
+
+func (g *jsonSchemaGenerator) baseType(schema *jsonSchema, name string) jen.Code {
+	schema, defName := g.deref(schema)
+
+	if defName != "" {
+		return jen.Id(defName)
+	}
+
+	switch schema.Type.primary() {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return jen.Qual("time", "Time")
+
+		default:
+			return jen.String()
+		}
+
+	case "integer":
+		return jen.Int()
+
+	case "number":
+		return jen.Float64()
+
+	case "boolean":
+		return jen.Bool()
+
+	case "array":
+		if schema.Items == nil {
+			return jen.Index().Any()
+		}
+
+		item := g.resolve(schema.Items, name+"Item")
+
+		itemType := item.base
+		if !item.object && len(item.checks) > 0 {
+			// validate each item separately
+			itemType = fieldType(item, true)
+		}
+
+		g.items[schema] = itemType
+
+		return jen.Index().Add(itemType)
+
+	case "object":
+		if schema.AdditionalProperties != nil && !schema.AdditionalProperties.boolean {
+			value := g.resolve(schema.AdditionalProperties, name+"Value")
+
+			if value.object || len(value.checks) == 0 {
+				return jen.Map(jen.String()).Add(value.base)
+			}
+
+			return jen.Map(jen.String()).Add(fieldType(value, true))
+		}
+
+		return jen.Map(jen.String()).Any()
+
+	default:
+		if len(schema.Enum) > 0 {
+			return enumType(schema.Enum)
+		}
+
+		return jen.Any()
+	}
+}
+
+func enumType(values []any) jen.Code {
+	switch values[0].(type) {
+	case string:
+		return jen.String()
+
+	case float64:
+		return jen.Float64()
+
+	case bool:
+		return jen.Bool()
+
+	default:
+		return jen.Any()
+	}
+}
+
+// checks returns validators for the schema.
+// Keywords not covered by the validate package are implemented by generated validator.
+// Kind is the JSON type of base, string and numeric keywords are skipped for other types.
+//
+//nolint:cyclop,funlen,gocognit,gocyclo,maintidx
+func (g *jsonSchemaGenerator) checks(schema *jsonSchema, base jen.Code, kind, name string, allowRef bool) []check {
+	if schema.Ref != "" {
+		if !allowRef {
+			return nil
+		}
+
+		_, defName := g.deref(schema)
+
+		return g.resolvedDefs[defName].checks
+	}
+
+	var (
+		checks []check
+		custom []jen.Code
+
+		// zod contains zod checks of custom validations for the zod generator
+		zod []string
+	)
+
+	builtin := func(name string, params ...jen.Code) {
+		if len(params) == 0 {
+			params = []jen.Code{base}
+		}
+
+		checks = append(checks, check{qual: validatePkg, name: name, params: params})
+	}
+
+	value := jen.Id("value")
+	text := jen.String().Call(value)
+	number := jen.Float64().Call(value)
+
+	fail := func(msg string) jen.Code {
+		return jen.Return(jen.Qual("errors", "New").Call(jen.Lit(msg)))
+	}
+
+	item, ok := g.items[schema]
+	if !ok {
+		item = jen.Any()
+	}
+
+	schema = g.applicable(schema, kind, name)
+
+	switch schema.Format {
+	case "":
+	case "email", "idn-email":
+		builtin("Email")
+
+	case "uri", "uri-reference", "iri", "iri-reference":
+		builtin("URL")
+
+	case "uuid":
+		builtin("UUID")
+
+	case "ipv4":
+		builtin("IPV4")
+
+	case "ipv6":
+		builtin("IPV6")
+
+	case "date-time":
+		// represented as time.Time
+
+	case "date":
+		custom = append(custom, jen.If(
+			jen.List(jen.Id("_"), jen.Err()).Op(":=").Qual("time", "Parse").Call(jen.Qual("time", "DateOnly"), text),
+			jen.Err().Op("!=").Nil(),
+		).Block(jen.Return(jen.Err())))
+
+		zod = append(zod, ".date()")
+
+	default:
+		log.Printf("%s: unsupported format %q is ignored", name, schema.Format)
+	}
+
+	if schema.Pattern != "" {
+		pattern := lowerFirst(name) + "Pattern"
+
+		g.validators = append(g.validators,
+			jen.Var().Id(pattern).Op("=").Qual("regexp", "MustCompile").Call(jen.Lit(schema.Pattern)),
+		)
+
+		custom = append(custom, jen.If(jen.Op("!").Id(pattern).Dot("MatchString").Call(text)).Block(
+			fail(fmt.Sprintf("does not match pattern %q", schema.Pattern)),
+		))
+
+		zod = append(zod, ".regex(new RegExp("+quote(schema.Pattern)+"))")
+	}
+
+	switch {
+	case schema.MinLength != nil && *schema.MinLength == 1 && schema.Type.primary() == "string":
+		builtin("NonZero")
+
+	case schema.MinLength != nil:
+		custom = append(custom, jen.If(
+			jen.Qual("unicode/utf8", "RuneCountInString").Call(text).Op("<").Lit(*schema.MinLength),
+		).Block(fail(fmt.Sprintf("shorter than %d characters", *schema.MinLength))))
+
+		zod = append(zod, fmt.Sprintf(".min(%d)", *schema.MinLength))
+	}
+
+	if schema.MaxLength != nil {
+		custom = append(custom, jen.If(
+			jen.Qual("unicode/utf8", "RuneCountInString").Call(text).Op(">").Lit(*schema.MaxLength),
+		).Block(fail(fmt.Sprintf("longer than %d characters", *schema.MaxLength))))
+
+		zod = append(zod, fmt.Sprintf(".max(%d)", *schema.MaxLength))
+	}
+
+	exclusiveMin, minimum := bounds(schema.ExclusiveMinimum, schema.Minimum)
+	exclusiveMax, maximum := bounds(schema.ExclusiveMaximum, schema.Maximum)
+
+	switch {
+	case exclusiveMin == nil:
+	case *exclusiveMin == 0:
+		builtin("Positive")
+
+	default:
+		custom = append(custom, jen.If(number.Clone().Op("<=").Lit(*exclusiveMin)).Block(
+			fail(fmt.Sprintf("must be greater than %v", *exclusiveMin)),
+		))
+
+		zod = append(zod, fmt.Sprintf(".gt(%v)", *exclusiveMin))
+	}
+
+	switch {
+	case minimum == nil:
+	case *minimum == 0:
+		builtin("Positive0")
+
+	default:
+		custom = append(custom, jen.If(number.Clone().Op("<").Lit(*minimum)).Block(
+			fail(fmt.Sprintf("must be greater than or equal to %v", *minimum)),
+		))
+
+		zod = append(zod, fmt.Sprintf(".gte(%v)", *minimum))
+	}
+
+	switch {
+	case exclusiveMax == nil:
+	case *exclusiveMax == 0:
+		builtin("Negative")
+
+	default:
+		custom = append(custom, jen.If(number.Clone().Op(">=").Lit(*exclusiveMax)).Block(
+			fail(fmt.Sprintf("must be less than %v", *exclusiveMax)),
+		))
+
+		zod = append(zod, fmt.Sprintf(".lt(%v)", *exclusiveMax))
+	}
+
+	switch {
+	case maximum == nil:
+	case *maximum == 0:
+		builtin("Negative0")
+
+	default:
+		custom = append(custom, jen.If(number.Clone().Op(">").Lit(*maximum)).Block(
+			fail(fmt.Sprintf("must be less than or equal to %v", *maximum)),
+		))
+
+		zod = append(zod, fmt.Sprintf(".lte(%v)", *maximum))
+	}
+
+	if schema.MultipleOf != nil {
+		if *schema.MultipleOf == 2 && schema.Type.primary() == "integer" {
+			builtin("Even")
+		} else {
+			custom = append(custom, jen.If(
+				jen.Qual("math", "Mod").Call(number, jen.Lit(*schema.MultipleOf)).Op("!=").Lit(0),
+			).Block(fail(fmt.Sprintf("must be a multiple of %v", *schema.MultipleOf))))
+
+			zod = append(zod, fmt.Sprintf(".multipleOf(%v)", *schema.MultipleOf))
+		}
+	}
+
+	if schema.Type.primary() == "array" {
+		if schema.MinItems != nil && *schema.MinItems == 1 {
+			builtin("NonEmpty", base, item)
+		} else if schema.MinItems != nil {
+			custom = append(custom, jen.If(jen.Len(value).Op("<").Lit(*schema.MinItems)).Block(
+				fail(fmt.Sprintf("must contain at least %d items", *schema.MinItems)),
+			))
+
+			zod = append(zod, fmt.Sprintf(".min(%d)", *schema.MinItems))
+		}
+
+		if schema.MaxItems != nil {
+			custom = append(custom, jen.If(jen.Len(value).Op(">").Lit(*schema.MaxItems)).Block(
+				fail(fmt.Sprintf("must contain at most %d items", *schema.MaxItems)),
+			))
+
+			zod = append(zod, fmt.Sprintf(".max(%d)", *schema.MaxItems))
+		}
+
+		if schema.UniqueItems {
+			custom = append(custom, jen.For(jen.Id("i").Op(":=").Range().Id("value")).Block(
+				jen.For(jen.Id("j").Op(":=").Range().Id("value").Index(jen.Op(":").Id("i"))).Block(
+					jen.If(jen.Qual("reflect", "DeepEqual").Call(
+						jen.Id("value").Index(jen.Id("i")),
+						jen.Id("value").Index(jen.Id("j")),
+					)).Block(fail("duplicate value found")),
+				),
+			))
+
+			zod = append(zod, zodUnique)
+		}
+	}
+
+	enum := schema.Enum
+
+	if len(schema.Const) > 0 {
+		var v any
+
+		if err := json.Unmarshal(schema.Const, &v); err != nil {
+			log.Fatalf("%s: invalid const: %v", name, err)
+		}
+
+		enum = append(enum, v)
+	}
+
+	if len(enum) > 0 {
+		literals := make([]jen.Code, 0, len(enum))
+		descriptions := make([]string, 0, len(enum))
+		values := make([]string, 0, len(enum))
+
+		for _, v := range enum {
+			if v == nil {
+				continue
+			}
+
+			literals = append(literals, jen.Lit(v))
+			descriptions = append(descriptions, fmt.Sprint(v))
+
+			//nolint:errchkjson // values are decoded from json
+			data, _ := json.Marshal(v)
+			values = append(values, string(data))
+		}
+
+		msg := "must be one of: " + strings.Join(descriptions, ", ")
+
+		custom = append(custom, jen.If(
+			jen.Op("!").Qual("slices", "Contains").Call(jen.Index().Add(base).Values(literals...), value),
+		).Block(fail(msg)))
+
+		zod = append(zod, fmt.Sprintf(
+			".refine((value) => [%s].includes(value), { message: %s })",
+			strings.Join(values, ", "), quote(msg),
+		))
+	}
+
+	for i, sub := range schema.AllOf {
+		checks = append(checks, g.checks(sub, base, kind, name+"AllOf"+strconv.Itoa(i+1), true)...)
+	}
+
+	if len(custom) > 0 {
+		validator := lowerFirst(name) + "Validator"
+
+		g.validators = append(g.validators,
+			jen.Commentf("%s implements validation keywords which are not covered by built-in validators.", validator),
+			jen.Comment("//"),
+		)
+
+		for _, z := range zod {
+			g.validators = append(g.validators, jen.Comment(zodDirective+z))
+		}
+
+		g.validators = append(g.validators,
+			jen.Type().Id(validator).Struct(),
+			jen.Func().Params(jen.Id(validator)).Id("Validate").Params(jen.Id("value").Add(base)).Error().BlockFunc(
+				func(g *jen.Group) {
+					for _, c := range custom {
+						g.Add(c)
+					}
+
+					g.Return(jen.Nil())
+				},
+			),
+		)
+
+		checks = append(checks, check{name: validator})
+	}
+
+	return checks
+}
+
+// kind returns JSON type of the schema values, which is inferred from enum if type is not declared.
+func (g *jsonSchemaGenerator) kind(schema *jsonSchema) string {
+	schema, _ = g.deref(schema)
+
+	if t := schema.Type.primary(); t != "" {
+		return t
+	}
+
+	if len(schema.Enum) > 0 {
+		switch schema.Enum[0].(type) {
+		case string:
+			return "string"
+
+		case float64:
+			return "number"
+
+		case bool:
+			return "boolean"
+		}
+	}
+
+	return ""
+}
+
+// applicable returns a copy of the schema without string and numeric keywords which do not apply to values of the kind,
+// since generated validators would not compile for them.
+func (g *jsonSchemaGenerator) applicable(schema *jsonSchema, kind, name string) *jsonSchema {
+	result := *schema
+
+	if kind != "string" && (result.Format != "" || result.Pattern != "" || result.MinLength != nil || result.MaxLength != nil) {
+		log.Printf("%s: format, pattern, minLength and maxLength apply to strings only, ignoring", name)
+
+		result.Format, result.Pattern, result.MinLength, result.MaxLength = "", "", nil, nil
+	}
+
+	numeric := kind == "integer" || kind == "number"
+
+	if !numeric && (result.Minimum != nil || result.Maximum != nil || len(result.ExclusiveMinimum) > 0 ||
+		len(result.ExclusiveMaximum) > 0 || result.MultipleOf != nil) {
+		log.Printf("%s: minimum, maximum and multipleOf apply to numbers only, ignoring", name)
+
+		result.Minimum, result.Maximum, result.ExclusiveMinimum, result.ExclusiveMaximum, result.MultipleOf = nil, nil, nil, nil, nil
+	}
+
+	return &result
+}
+
+// bounds returns exclusive and inclusive bounds of a number.
+// Draft 4 declares exclusive bounds as a boolean modifier of minimum and maximum,
+// later drafts declare them as separate numbers, so both bounds may be set.
+func bounds(exclusive json.RawMessage, inclusive *float64) (*float64, *float64) {
+	if len(exclusive) == 0 {
+		return nil, inclusive
+	}
+
+	var flag bool
+
+	if err := json.Unmarshal(exclusive, &flag); err == nil {
+		if flag {
+			return inclusive, nil
+		}
+
+		return nil, inclusive
+	}
+
+	var value float64
+
+	if err := json.Unmarshal(exclusive, &value); err != nil || math.IsNaN(value) {
+		return nil, inclusive
+	}
+
+	return &value, inclusive
+}
+
+// packageName returns name of the go package in dir.
+// Falls back to the directory name if there are no go files.
+func packageName(dir string) string {
+	pkgs := parsePackages(dir, ".")
+
+	for _, pkg := range pkgs {
+		if pkg.Name != "" && !strings.HasSuffix(pkg.Name, "_test") {
+			return pkg.Name
+		}
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+
+		return -1
+	}, filepath.Base(abs))
+}
+
+//nolint:gochecknoglobals
+var initialisms = []string{"ID", "URL", "URI", "UUID", "API", "HTTP", "JSON", "IP", "SQL", "XML"}
+
+// goName converts arbitrary name (e.g. "user_id", "created-at") to an exported go identifier (e.g. "UserID", "CreatedAt").
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+
+	for _, word := range words {
+		for _, part := range splitCamel(word) {
+			if upper := strings.ToUpper(part); slices.Contains(initialisms, upper) {
+				b.WriteString(upper)
+
+				continue
+			}
+
+			runes := []rune(part)
+			runes[0] = unicode.ToUpper(runes[0])
+
+			b.WriteString(string(runes))
+		}
+	}
+
+	result := b.String()
+
+	if result != "" && unicode.IsDigit([]rune(result)[0]) {
+		result = "X" + result
+	}
+
+	return result
+}
+
+// splitCamel splits camel case word into parts, e.g. "userId" into "user" and "Id".
+func splitCamel(word string) []string {
+	var (
+		parts []string
+		start int
+	)
+
+	runes := []rune(word)
+
+	for i := 1; i < len(runes); i++ {
+		if unicode.IsUpper(runes[i]) && !unicode.IsUpper(runes[i-1]) {
+			parts = append(parts, string(runes[start:i]))
+			start = i
+		}
+	}
+
+	return append(parts, string(runes[start:]))
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/metafates/schema/internal/testutil"
+)
+
+func TestGenFromJSONSchema(t *testing.T) {
+	dir := outputDir(t)
+
+	structs := genFromJSONSchema("testdata/jsonschema/user.schema.json", dir, "User")
+	testutil.DeepEqual(t, []string{"User", "Address"}, structs)
+
+	got, err := os.ReadFile(filepath.Join(dir, "User_jsonschema.go"))
+	testutil.NoError(t, err)
+
+	golden(t, "testdata/jsonschema/User_jsonschema.go.golden", got)
+
+	g := generator{types: structs}
+	g.genPackages(dir, ".")
+
+	build(t, dir)
+}
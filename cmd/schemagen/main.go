@@ -16,7 +16,27 @@ import (
 )
 
 //nolint:gochecknoglobals // this is pretty common in go have flags as global variables
-var flagType = flag.String("type", "", "comma-separated list of type names; must be set")
+var (
+	flagType = flag.String("type", "", "comma-separated list of type names; must be set")
+
+	flagFromJSONSchema = flag.String(
+		"from-jsonschema",
+		"",
+		"path to a JSON Schema file to generate types from; -type sets the name of the root type",
+	)
+
+	flagFromProto = flag.String(
+		"from-proto",
+		"",
+		"path to a protobuf descriptor set (protoc --descriptor_set_out) to generate types from",
+	)
+
+	flagZod = flag.String(
+		"zod",
+		"",
+		"path to a TypeScript file to write interfaces and zod schemas of the types to",
+	)
+)
 
 func Usage() {
 	printf := func(format string, a ...any) {
@@ -26,6 +46,9 @@ func Usage() {
 	printf("Schemagen is a tool to generate Go code for field-traversal validation\n")
 	printf("Usage of %s:\n", os.Args[0])
 	printf("\tschemagen [flags] -type T [directory]\n")
+	printf("\tschemagen [flags] -from-jsonschema file.json [-type T] [directory]\n")
+	printf("\tschemagen [flags] -from-proto descriptors.binpb [directory]\n")
+	printf("\tschemagen [flags] -type T -zod schema.ts [directory]\n")
 	printf("For more information, see:\n")
 	printf("\thttps://github.com/metafates/schema\n")
 	printf("Flags:\n")
@@ -40,20 +63,48 @@ func main() {
 	flag.Usage = Usage
 	flag.Parse()
 
-	if len(*flagType) == 0 {
-		flag.Usage()
-		os.Exit(2)
-	}
-
 	args := flag.Args()
 	if len(args) == 0 {
 		// Default: process whole package in current directory.
 		args = []string{"."}
 	}
 
+	if *flagFromJSONSchema != "" {
+		if len(args) != 1 {
+			log.Fatal("exactly one output directory must be specified for -from-jsonschema")
+		}
+
+		dir := args[0]
+
+		g := generator{types: genFromJSONSchema(*flagFromJSONSchema, dir, *flagType)}
+
+		g.genPackages(dir, ".")
+
+		return
+	}
+
+	if *flagFromProto != "" {
+		if len(args) != 1 {
+			log.Fatal("exactly one output directory must be specified for -from-proto")
+		}
+
+		dir := args[0]
+
+		g := generator{types: genFromProto(*flagFromProto, dir)}
+
+		g.genPackages(dir, ".")
+
+		return
+	}
+
+	if len(*flagType) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
 	g := generator{types: strings.Split(*flagType, ",")}
 
-	g.genPackages(args...)
+	g.genPackages("", args...)
 }
 
 type generator struct {
@@ -71,8 +122,8 @@ type generator struct {
 // from which they were generated.
 //
 // Types will be excluded when generated, to avoid repetitions.
-func (g *generator) genPackages(patterns ...string) {
-	pkgs := parsePackages(patterns...)
+func (g *generator) genPackages(dir string, patterns ...string) {
+	pkgs := parsePackages(dir, patterns...)
 
 	slices.SortFunc(pkgs, func(a, b *packages.Package) int {
 		aTest := strings.HasSuffix(a.Name, "_test")
@@ -129,6 +180,10 @@ func (g *generator) genPackage(pkg *packages.Package) {
 
 	g.types = remainingTypes
 
+	if *flagZod != "" {
+		genZod(*flagZod, pkg, foundTypes)
+	}
+
 	isTest := hasTestFiles(pkg)
 
 	for _, name := range foundTypes {
@@ -160,10 +215,13 @@ func (g *generator) genPackage(pkg *packages.Package) {
 	}
 }
 
-func parsePackages(patterns ...string) []*packages.Package {
+// parsePackages loads packages matching patterns.
+// Patterns are resolved relative to dir, current directory is used if dir is empty.
+func parsePackages(dir string, patterns ...string) []*packages.Package {
 	cfg := packages.Config{
 		Mode:  packages.LoadAllSyntax,
 		Tests: true,
+		Dir:   dir,
 	}
 
 	pkgs, err := packages.Load(&cfg, patterns...)
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/metafates/schema/internal/testutil"
+)
+
+//nolint:gochecknoglobals // test flag
+var update = flag.Bool("update", false, "update golden files")
+
+// golden compares got with the contents of the golden file at path.
+// The file is overwritten instead if -update flag is set.
+func golden(t *testing.T, path string, got []byte) {
+	t.Helper()
+
+	if *update {
+		//nolint:gosec,mnd // golden files are not secret
+		testutil.NoError(t, os.WriteFile(path, got, 0o644))
+
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	testutil.NoError(t, err)
+	testutil.Equal(t, string(want), string(got))
+}
+
+// outputDir creates a package directory inside the module for generated files, so that they can be compiled.
+// The directory is removed after the test.
+func outputDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("testdata", "out")
+	testutil.NoError(t, err)
+
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+
+	//nolint:gosec,mnd // test file
+	testutil.NoError(t, os.WriteFile(filepath.Join(dir, "doc.go"), []byte("package gen\n"), 0o644))
+
+	return dir
+}
+
+// build compiles the package in dir.
+func build(t *testing.T, dir string) {
+	t.Helper()
+
+	out, err := exec.Command("go", "vet", "./"+dir).CombinedOutput()
+	if err != nil {
+		t.Fatalf("generated code does not compile: %v\n%s", err, out)
+	}
+}
@@ -0,0 +1,470 @@
+package main
+
+import (
+	"go/token"
+	"go/types"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dave/jennifer/jen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// protoAnnotation is a prefix of field comment lines which set schema type of the field.
+// For example:
+//
+//	// schema: required.Email
+//	string email = 3;
+const protoAnnotation = "schema:"
+
+type protoGenerator struct {
+	file *jen.File
+	dir  string
+
+	// pkgs caches loaded packages of annotated types.
+	pkgs map[string]*types.Package
+
+	// generate contains full names of messages and enums which are generated.
+	generate map[protoreflect.FullName]bool
+
+	// structs contains names of all generated struct types in the declaration order.
+	structs []string
+}
+
+// genFromProto generates go types for messages and enums of the proto files
+// in the descriptor set at path and writes them into dir.
+// Files from google/protobuf are skipped.
+// It returns names of generated struct types.
+func genFromProto(path, dir string) []string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+
+	if err := proto.Unmarshal(data, &set); err != nil {
+		log.Fatalf("parse %s: %v", path, err)
+	}
+
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		log.Fatalf("parse %s: %v", path, err)
+	}
+
+	var fds []protoreflect.FileDescriptor
+
+	for _, fdp := range set.GetFile() {
+		if strings.HasPrefix(fdp.GetName(), "google/protobuf/") {
+			continue
+		}
+
+		fd, err := files.FindFileByPath(fdp.GetName())
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		fds = append(fds, fd)
+	}
+
+	generate := make(map[protoreflect.FullName]bool)
+
+	for _, fd := range fds {
+		markProto(generate, fd.Enums(), fd.Messages())
+	}
+
+	pkgName := packageName(dir)
+
+	pkgs := make(map[string]*types.Package)
+
+	var structs []string
+
+	for _, fd := range fds {
+		f := jen.NewFile(pkgName)
+		f.HeaderComment("Code generated by schemagen; DO NOT EDIT.")
+		f.HeaderComment("source: " + fd.Path())
+
+		g := protoGenerator{
+			file:     f,
+			dir:      dir,
+			pkgs:     pkgs,
+			generate: generate,
+		}
+
+		g.genFile(fd)
+
+		basename := strings.TrimSuffix(filepath.Base(fd.Path()), filepath.Ext(fd.Path()))
+		outputPath := filepath.Join(dir, basename+"_proto.go")
+
+		if err := f.Save(outputPath); err != nil {
+			log.Fatalln(err)
+		}
+
+		structs = append(structs, g.structs...)
+	}
+
+	return structs
+}
+
+// markProto adds full names of enums and messages, including nested ones, to the set.
+func markProto(set map[protoreflect.FullName]bool, enums protoreflect.EnumDescriptors, messages protoreflect.MessageDescriptors) {
+	for i := range enums.Len() {
+		set[enums.Get(i).FullName()] = true
+	}
+
+	for i := range messages.Len() {
+		md := messages.Get(i)
+
+		set[md.FullName()] = true
+
+		markProto(set, md.Enums(), md.Messages())
+	}
+}
+
+func (g *protoGenerator) genFile(fd protoreflect.FileDescriptor) {
+	for i := range fd.Enums().Len() {
+		g.genEnum(fd.Enums().Get(i))
+	}
+
+	for i := range fd.Messages().Len() {
+		g.genMessage(fd.Messages().Get(i))
+	}
+}
+
+func (g *protoGenerator) genEnum(ed protoreflect.EnumDescriptor) {
+	name := protoGoName(ed)
+
+	// values of top-level enums are prefixed with the enum name, nested ones with the message name
+	prefix := name
+	if md, ok := ed.Parent().(protoreflect.MessageDescriptor); ok {
+		prefix = protoGoName(md)
+	}
+
+	values := make([]jen.Code, 0, ed.Values().Len())
+
+	for i := range ed.Values().Len() {
+		vd := ed.Values().Get(i)
+
+		values = append(values, jen.Id(prefix+"_"+string(vd.Name())).Id(name).Op("=").Lit(int(vd.Number())))
+	}
+
+	g.comment(ed, name)
+	g.file.Type().Id(name).Int32()
+	g.file.Line()
+	g.file.Const().Defs(values...)
+	g.file.Line()
+}
+
+func (g *protoGenerator) genMessage(md protoreflect.MessageDescriptor) {
+	if md.IsMapEntry() {
+		return
+	}
+
+	name := protoGoName(md)
+
+	g.structs = append(g.structs, name)
+
+	fields := make([]jen.Code, 0, md.Fields().Len())
+
+	for i := range md.Fields().Len() {
+		fd := md.Fields().Get(i)
+
+		if oneof := fd.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			log.Printf("%s: oneof fields are not supported, skipping", fd.FullName())
+
+			continue
+		}
+
+		field := jen.Id(protoCamelCase(string(fd.Name()))).
+			Add(g.fieldType(fd)).
+			Tag(map[string]string{"json": fd.JSONName()})
+
+		loc := fd.ParentFile().SourceLocations().ByDescriptor(fd)
+
+		for _, line := range commentLines(loc.LeadingComments) {
+			if !strings.HasPrefix(line, protoAnnotation) {
+				fields = append(fields, jen.Comment(line))
+			}
+		}
+
+		fields = append(fields, field)
+	}
+
+	g.comment(md, name)
+	g.file.Type().Id(name).Struct(fields...)
+	g.file.Line()
+
+	for i := range md.Enums().Len() {
+		g.genEnum(md.Enums().Get(i))
+	}
+
+	for i := range md.Messages().Len() {
+		g.genMessage(md.Messages().Get(i))
+	}
+}
+
+// fieldType returns go type of the field.
+//
+// Fields annotated with "schema: required.X" or "schema: optional.X" comment use corresponding types.
+// Fields with explicit presence (proto3 optional and proto2 fields) are optional.Any by default.
+// Other fields use plain go types.
+func (g *protoGenerator) fieldType(fd protoreflect.FieldDescriptor) jen.Code {
+	base := g.valueType(fd)
+
+	pkg, name := protoFieldAnnotation(fd)
+
+	if pkg != "" && fd.Message() != nil && !fd.IsList() && !fd.IsMap() {
+		log.Printf("%s: annotations of message fields are not supported, ignoring", fd.FullName())
+
+		pkg = ""
+	}
+
+	switch {
+	case pkg != "":
+		return jen.Qual(pkg, name).Types(g.annotationTypes(pkg, name, fd, base)...)
+
+	case fd.Cardinality() == protoreflect.Required:
+		return jen.Qual(requiredPkg, "Any").Types(base)
+
+	case fd.HasPresence() && fd.Message() == nil:
+		return jen.Qual(optionalPkg, "Any").Types(base)
+
+	default:
+		return base
+	}
+}
+
+// annotationTypes returns type arguments of the annotated type.
+//
+// Most types accept the field type.
+// For repeated fields, types such as NonEmptySlice[T] accept the element type
+// and types such as NonEmpty[S, T] accept both.
+func (g *protoGenerator) annotationTypes(pkgPath, name string, fd protoreflect.FieldDescriptor, base jen.Code) []jen.Code {
+	pkg, ok := g.pkgs[pkgPath]
+	if !ok {
+		for _, p := range parsePackages(g.dir, pkgPath) {
+			if p.ID == pkgPath {
+				pkg = p.Types
+			}
+		}
+
+		if pkg == nil {
+			log.Fatalf("%s: package %s not found", fd.FullName(), pkgPath)
+		}
+
+		g.pkgs[pkgPath] = pkg
+	}
+
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		log.Fatalf("%s: unknown type %s.%s", fd.FullName(), pkg.Name(), name)
+	}
+
+	alias, ok := obj.Type().(*types.Alias)
+	if !ok || !fd.IsList() {
+		return []jen.Code{base}
+	}
+
+	elem := g.kindType(fd)
+
+	switch alias.TypeParams().Len() {
+	case 2:
+		return []jen.Code{base, elem}
+
+	case 1:
+		if named, ok := alias.Rhs().(*types.Named); ok && named.TypeArgs().Len() > 0 {
+			if _, ok := named.TypeArgs().At(0).(*types.Slice); ok {
+				return []jen.Code{elem}
+			}
+		}
+	}
+
+	return []jen.Code{base}
+}
+
+// valueType returns plain go type of the field.
+func (g *protoGenerator) valueType(fd protoreflect.FieldDescriptor) jen.Code {
+	switch {
+	case fd.IsMap():
+		return jen.Map(g.kindType(fd.MapKey())).Add(g.kindType(fd.MapValue()))
+
+	case fd.IsList():
+		return jen.Index().Add(g.kindType(fd))
+
+	default:
+		return g.kindType(fd)
+	}
+}
+
+func (g *protoGenerator) kindType(fd protoreflect.FieldDescriptor) jen.Code {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return jen.Bool()
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return jen.Int32()
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return jen.Int64()
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return jen.Uint32()
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return jen.Uint64()
+
+	case protoreflect.FloatKind:
+		return jen.Float32()
+
+	case protoreflect.DoubleKind:
+		return jen.Float64()
+
+	case protoreflect.StringKind:
+		return jen.String()
+
+	case protoreflect.BytesKind:
+		return jen.Index().Byte()
+
+	case protoreflect.EnumKind:
+		if !g.generate[fd.Enum().FullName()] {
+			log.Printf("%s: enum %s is not generated, using int32", fd.FullName(), fd.Enum().FullName())
+
+			return jen.Int32()
+		}
+
+		return jen.Id(protoGoName(fd.Enum()))
+
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if !g.generate[fd.Message().FullName()] {
+			log.Printf("%s: message %s is not generated, using any", fd.FullName(), fd.Message().FullName())
+
+			return jen.Any()
+		}
+
+		return jen.Id(protoGoName(fd.Message()))
+
+	default:
+		log.Fatalf("%s: unsupported kind %s", fd.FullName(), fd.Kind())
+
+		return nil
+	}
+}
+
+func (g *protoGenerator) comment(d protoreflect.Descriptor, name string) {
+	loc := d.ParentFile().SourceLocations().ByDescriptor(d)
+
+	lines := commentLines(loc.LeadingComments)
+
+	if len(lines) == 0 {
+		g.file.Comment(name + " is generated from " + string(d.FullName()) + ".")
+
+		return
+	}
+
+	for _, line := range lines {
+		g.file.Comment(line)
+	}
+}
+
+// protoFieldAnnotation returns package path and type name from the schema annotation of the field.
+func protoFieldAnnotation(fd protoreflect.FieldDescriptor) (pkg, name string) {
+	loc := fd.ParentFile().SourceLocations().ByDescriptor(fd)
+
+	for _, line := range append(commentLines(loc.LeadingComments), commentLines(loc.TrailingComments)...) {
+		value, ok := strings.CutPrefix(line, protoAnnotation)
+		if !ok {
+			continue
+		}
+
+		value = strings.TrimSpace(value)
+
+		pkgName, name, _ := strings.Cut(value, ".")
+		if name == "" {
+			name = "Any"
+		}
+
+		if !token.IsIdentifier(name) {
+			log.Fatalf("%s: invalid annotation %q", fd.FullName(), line)
+		}
+
+		switch pkgName {
+		case "required":
+			return requiredPkg, name
+
+		case "optional":
+			return optionalPkg, name
+
+		default:
+			log.Fatalf("%s: invalid annotation %q: must start with required or optional", fd.FullName(), line)
+		}
+	}
+
+	return "", ""
+}
+
+func commentLines(comments string) []string {
+	var lines []string
+
+	for line := range strings.Lines(strings.TrimSpace(comments)) {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+
+	return lines
+}
+
+// protoGoName returns go name of the message or enum as generated by protoc-gen-go,
+// e.g. "Person_PhoneNumber" for the nested message "Person.PhoneNumber".
+func protoGoName(d protoreflect.Descriptor) string {
+	name := strings.TrimPrefix(string(d.FullName()), string(d.ParentFile().Package())+".")
+
+	return protoCamelCase(name)
+}
+
+// protoCamelCase converts proto name to go name the same way as protoc-gen-go does.
+func protoCamelCase(s string) string {
+	isLower := func(c byte) bool { return 'a' <= c && c <= 'z' }
+	isDigit := func(c byte) bool { return '0' <= c && c <= '9' }
+
+	var b []byte
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+
+		switch {
+		case c == '.' && i+1 < len(s) && isLower(s[i+1]):
+			// skip over '.' in ".{{lowercase}}"
+
+		case c == '.':
+			b = append(b, '_')
+
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			// convert initial '_' to ensure we start with a capital letter
+			b = append(b, 'X')
+
+		case c == '_' && i+1 < len(s) && isLower(s[i+1]):
+			// skip over '_' in "_{{lowercase}}"
+
+		case isDigit(c):
+			b = append(b, c)
+
+		default:
+			if isLower(c) {
+				c -= 'a' - 'A'
+			}
+
+			b = append(b, c)
+
+			// accept lower case sequence that follows
+			for ; i+1 < len(s) && isLower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+
+	return string(b)
+}
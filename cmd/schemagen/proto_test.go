@@ -0,0 +1,149 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	"github.com/metafates/schema/internal/testutil"
+)
+
+func TestGenFromProto(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		binpb   string
+		output  string
+		structs []string
+	}{
+		{
+			// generated with:
+			//
+			//	protoc --descriptor_set_out=example.binpb --include_source_info example.proto
+			name:    "annotated proto3",
+			binpb:   "testdata/proto/example.binpb",
+			output:  "example_proto.go",
+			structs: []string{"Person", "Person_PhoneNumber", "AddressBook"},
+		},
+		{
+			name:    "proto2 presence, maps and enums",
+			binpb:   writeDescriptorSet(t, proto2File()),
+			output:  "inventory_proto.go",
+			structs: []string{"Item", "Inventory"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := outputDir(t)
+
+			structs := genFromProto(tc.binpb, dir)
+			testutil.DeepEqual(t, tc.structs, structs)
+
+			got, err := os.ReadFile(filepath.Join(dir, tc.output))
+			testutil.NoError(t, err)
+
+			golden(t, filepath.Join("testdata/proto", tc.output+".golden"), got)
+
+			g := generator{types: structs}
+			g.genPackages(dir, ".")
+
+			build(t, dir)
+		})
+	}
+}
+
+func writeDescriptorSet(t *testing.T, files ...*descriptorpb.FileDescriptorProto) string {
+	t.Helper()
+
+	data, err := proto.Marshal(&descriptorpb.FileDescriptorSet{File: files})
+	testutil.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "set.binpb")
+
+	//nolint:gosec,mnd // test file
+	testutil.NoError(t, os.WriteFile(path, data, 0o644))
+
+	return path
+}
+
+// proto2File returns descriptor of the following file:
+//
+//	syntax = "proto2";
+//	package inventory;
+//
+//	enum Kind { KIND_UNKNOWN = 0; KIND_BOOK = 1; }
+//
+//	message Item {
+//	  required string name = 1;
+//	  optional double price = 2;
+//	  repeated string tags = 3;
+//	  optional Kind kind = 4;
+//	}
+//
+//	message Inventory {
+//	  map<string, Item> items = 1;
+//	  repeated bytes blobs = 2;
+//	}
+func proto2File() *descriptorpb.FileDescriptorProto {
+	field := func(name string, number int32, label descriptorpb.FieldDescriptorProto_Label, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    label.Enum(),
+			Type:     typ.Enum(),
+			JsonName: proto.String(name),
+		}
+
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+
+		return f
+	}
+
+	const (
+		required = descriptorpb.FieldDescriptorProto_LABEL_REQUIRED
+		optional = descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+		repeated = descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+	)
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("inventory.proto"),
+		Package: proto.String("inventory"),
+		Syntax:  proto.String("proto2"),
+		EnumType: []*descriptorpb.EnumDescriptorProto{{
+			Name: proto.String("Kind"),
+			Value: []*descriptorpb.EnumValueDescriptorProto{
+				{Name: proto.String("KIND_UNKNOWN"), Number: proto.Int32(0)},
+				{Name: proto.String("KIND_BOOK"), Number: proto.Int32(1)},
+			},
+		}},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Item"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, required, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("price", 2, optional, descriptorpb.FieldDescriptorProto_TYPE_DOUBLE, ""),
+					field("tags", 3, repeated, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					field("kind", 4, optional, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".inventory.Kind"),
+				},
+			},
+			{
+				Name: proto.String("Inventory"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("items", 1, repeated, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".inventory.Inventory.ItemsEntry"),
+					field("blobs", 2, repeated, descriptorpb.FieldDescriptorProto_TYPE_BYTES, ""),
+				},
+				NestedType: []*descriptorpb.DescriptorProto{{
+					Name: proto.String("ItemsEntry"),
+					Field: []*descriptorpb.FieldDescriptorProto{
+						field("key", 1, optional, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+						field("value", 2, optional, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".inventory.Item"),
+					},
+					Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+				}},
+			},
+		},
+	}
+}
@@ -0,0 +1,69 @@
+// Package fixture contains types for zod generator tests.
+package fixture
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+	"github.com/metafates/schema/transform"
+	"github.com/metafates/schema/validate"
+)
+
+type Role string
+
+const (
+	RoleAdmin Role = "admin"
+	RoleUser  Role = "user"
+)
+
+var slugPattern = regexp.MustCompile(`^[a-z-]+$`)
+
+// slug is a custom validator with zod directive.
+//
+//schemagen:zod .regex(/^[a-z-]+$/)
+type slug struct{}
+
+func (slug) Validate(value string) error {
+	if !slugPattern.MatchString(value) {
+		return errors.New("invalid slug")
+	}
+
+	return nil
+}
+
+// dashes is a custom transformer with zod directive.
+//
+//schemagen:zod .transform((value) => value.replaceAll(" ", "-"))
+type dashes struct{}
+
+func (dashes) Transform(value string) string {
+	return strings.ReplaceAll(value, " ", "-")
+}
+
+type User struct {
+	// transformers are chained before checks
+	Name required.Custom[string, transform.Pipe[string, transform.TrimLower[string], validate.NonZero[string]]] `json:"name"`
+
+	// checks are applied to each branch of the union
+	Contact required.Custom[string, validate.And[string, validate.Or[string, validate.Email[string], validate.UUID[string]], validate.NonZero[string]]] `json:"contact"`
+
+	// refinement is applied after the chainable checks
+	Handle optional.Custom[string, validate.And[string, validate.Not[string, validate.Email[string]], slug]] `json:"handle"`
+
+	// neither side is chainable
+	Code required.Custom[string, validate.And[string, validate.Or[string, validate.UUID[string], validate.Email[string]], validate.Not[string, validate.Zero[string]]]] `json:"code"`
+
+	// refine of NonZero number is not chainable
+	Score optional.Custom[int, validate.And[int, validate.NonZero[int], validate.Positive[int]]] `json:"score"`
+
+	// custom transformer is not chainable
+	Path required.Custom[string, transform.Pipe[string, dashes, validate.NonZero[string]]] `json:"path"`
+
+	Role    required.Any[Role]         `json:"role"`
+	Tags    []required.NonZero[string] `json:"tags"`
+	Friends []User                     `json:"friends,omitempty"`
+	Email   optional.Email[string]     `json:"email"`
+}
@@ -0,0 +1,654 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"log"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// zodDirective is a prefix of doc comment lines of custom validators which set zod checks for them.
+// For example:
+//
+//	//schemagen:zod .regex(/^[a-z-]+$/)
+//	type slug struct{}
+const zodDirective = "//schemagen:zod "
+
+// zodCategory is a kind of zod schema which determines applicable checks.
+type zodCategory int
+
+const (
+	zodOther zodCategory = iota
+	zodString
+	zodNumber
+	zodDateTime
+	zodArray
+)
+
+// zodType is a TypeScript type and a zod schema of a go type.
+type zodType struct {
+	schema   string
+	ts       string
+	category zodCategory
+
+	// unchecked is the schema of the type without checks, which negated checks are built on.
+	unchecked string
+
+	// nullable reports whether zero value of the type is encoded as null.
+	nullable bool
+
+	// optional reports whether the type is optional.Custom.
+	optional bool
+}
+
+func (t zodType) orNull() zodType {
+	if !t.nullable {
+		return t
+	}
+
+	t.schema += ".nullable()"
+	t.ts += " | null"
+	t.nullable = false
+
+	return t
+}
+
+const zodUnique = `.refine((items) => new Set(items.map((item) => JSON.stringify(item))).size === items.length, { message: "duplicate value found" })`
+
+const transformPkg = "github.com/metafates/schema/transform"
+
+//nolint:gochecknoglobals
+var (
+	// zodTransforms contains zod transformations for string transformers of the transform package.
+	zodTransforms = map[string]string{
+		"Trim":  ".trim()",
+		"Lower": ".toLowerCase()",
+		"Upper": ".toUpperCase()",
+	}
+
+	// zodChecks contains zod checks for validators of the validate package.
+	zodChecks = map[zodCategory]map[string]string{
+		zodString: {
+			"Zero":    ".length(0)",
+			"NonZero": ".min(1)",
+			"Email":   ".email()",
+			"URL":     ".url()",
+			"HTTPURL": `.url().regex(/^https?:\/\//i)`,
+			"IP":      ".ip()",
+			"IPV4":    `.ip({ version: "v4" })`,
+			"IPV6":    `.ip({ version: "v6" })`,
+			"CIDR":    ".cidr()",
+			"MAC":     `.regex(/^([0-9a-f]{2}[:-]){5,19}[0-9a-f]{2}$/i)`,
+			"Base64":  ".base64()",
+			"UUID":    ".uuid()",
+			"JSON":    `.refine((value) => { try { JSON.parse(value); return true; } catch { return false; } }, { message: "invalid json" })`,
+		},
+		zodNumber: {
+			"Zero":      `.refine((value) => value === 0, { message: "non-zero value" })`,
+			"NonZero":   `.refine((value) => value !== 0, { message: "zero value" })`,
+			"Positive":  ".positive()",
+			"Negative":  ".negative()",
+			"Positive0": ".nonnegative()",
+			"Negative0": ".nonpositive()",
+			"Even":      ".multipleOf(2)",
+			"Odd":       `.refine((value) => value % 2 !== 0, { message: "even value" })`,
+			"Latitude":  ".min(-90).max(90)",
+			"Longitude": ".min(-180).max(180)",
+		},
+		zodDateTime: {
+			"NonZero":  "",
+			"InPast":   `.refine((value) => new Date(value) < new Date(), { message: "time is not in the past" })`,
+			"InFuture": `.refine((value) => new Date(value) > new Date(), { message: "time is not in the future" })`,
+		},
+		zodArray: {
+			"NonEmpty":      ".nonempty()",
+			"NonEmptySlice": ".nonempty()",
+			"Unique":        zodUnique,
+			"UniqueSlice":   zodUnique,
+		},
+	}
+)
+
+type zodGenerator struct {
+	// pkgs maps type-checked packages to loaded packages, which contain syntax.
+	pkgs map[*types.Package]*packages.Package
+
+	// declared maps type names to their TypeScript names.
+	declared map[*types.TypeName]string
+
+	// done contains type names which declarations are written.
+	done map[*types.TypeName]bool
+
+	decls []string
+}
+
+// genZod writes TypeScript interfaces and zod schemas for the named types of the package to path.
+func genZod(path string, pkg *packages.Package, names []string) {
+	g := zodGenerator{
+		pkgs:     make(map[*types.Package]*packages.Package),
+		declared: make(map[*types.TypeName]string),
+		done:     make(map[*types.TypeName]bool),
+	}
+
+	packages.Visit([]*packages.Package{pkg}, nil, func(p *packages.Package) {
+		g.pkgs[p.Types] = p
+	})
+
+	for _, name := range names {
+		obj, ok := pkg.Types.Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			log.Fatalf("%s is not a type", name)
+		}
+
+		g.declare(obj)
+	}
+
+	var b strings.Builder
+
+	b.WriteString("// Code generated by schemagen; DO NOT EDIT.\n\n")
+	b.WriteString("import { z } from \"zod\";\n")
+
+	for _, decl := range g.decls {
+		b.WriteString("\n")
+		b.WriteString(decl)
+	}
+
+	//nolint:gosec,mnd // generated file is not a secret
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// declare adds declaration of the named type if it was not declared yet and returns a reference to it.
+func (g *zodGenerator) declare(obj *types.TypeName) zodType {
+	name, ok := g.declared[obj]
+	if !ok {
+		name = obj.Name()
+
+		if slices.Contains(slices.Collect(maps.Values(g.declared)), name) {
+			name = obj.Pkg().Name() + name
+		}
+
+		g.declared[obj] = name
+
+		if enum := g.enum(obj, name); enum != "" {
+			g.decls = append(g.decls, enum)
+		} else {
+			g.decls = append(g.decls, g.object(obj, name))
+		}
+
+		g.done[obj] = true
+	}
+
+	ref := zodType{schema: name + "Schema", ts: name}
+
+	if !g.done[obj] {
+		// recursive type
+		ref.schema = "z.lazy(() => " + ref.schema + ")"
+	}
+
+	return ref
+}
+
+func (g *zodGenerator) object(obj *types.TypeName, name string) string {
+	s, ok := obj.Type().Underlying().(*types.Struct)
+	if !ok {
+		t := g.zodType(obj.Type().Underlying()).orNull()
+
+		return fmt.Sprintf(
+			"export type %s = %s;\n\nexport const %sSchema: z.ZodType<%s> = %s;\n",
+			name, t.ts, name, name, t.schema,
+		)
+	}
+
+	var ts, schema strings.Builder
+
+	fmt.Fprintf(&ts, "export interface %s {\n", name)
+	fmt.Fprintf(&schema, "export const %sSchema: z.ZodType<%s> = z.object({\n", name, name)
+
+	for _, f := range g.fields(s) {
+		fmt.Fprintf(&ts, "  %s;\n", f[0])
+		fmt.Fprintf(&schema, "  %s,\n", f[1])
+	}
+
+	ts.WriteString("}\n")
+	schema.WriteString("});\n")
+
+	return ts.String() + "\n" + schema.String()
+}
+
+// fields returns TypeScript and zod declarations of the struct fields as encoded by encoding/json.
+func (g *zodGenerator) fields(s *types.Struct) [][2]string {
+	var fields [][2]string
+
+	for i := range s.NumFields() {
+		field := s.Field(i)
+
+		tag := reflect.StructTag(s.Tag(i)).Get("json")
+		if tag == "-" {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Embedded() && name == "" {
+			if embedded, ok := derefType(field.Type()).Underlying().(*types.Struct); ok {
+				fields = append(fields, g.fields(embedded)...)
+
+				continue
+			}
+		}
+
+		if !field.Exported() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name()
+		}
+
+		t := g.zodType(field.Type())
+
+		optional := t.optional || slices.Contains(strings.Split(opts, ","), "omitempty")
+
+		t = t.orNull()
+
+		if optional {
+			t.schema += ".optional()"
+		}
+
+		key := name
+		if !token.IsIdentifier(key) {
+			key = quote(key)
+		}
+
+		if optional {
+			fields = append(fields, [2]string{key + "?: " + t.ts, key + ": " + t.schema})
+		} else {
+			fields = append(fields, [2]string{key + ": " + t.ts, key + ": " + t.schema})
+		}
+	}
+
+	return fields
+}
+
+// enum returns declaration of the named type if it has constants declared in the same package.
+func (g *zodGenerator) enum(obj *types.TypeName, name string) string {
+	var values []string
+
+	scope := obj.Pkg().Scope()
+
+	for _, n := range scope.Names() {
+		c, ok := scope.Lookup(n).(*types.Const)
+		if !ok || !types.Identical(c.Type(), obj.Type()) {
+			continue
+		}
+
+		value := c.Val().ExactString()
+
+		if c.Val().Kind() == constant.String {
+			value = quote(constant.StringVal(c.Val()))
+		}
+
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	var schema string
+
+	if basic, ok := obj.Type().Underlying().(*types.Basic); ok && basic.Info()&types.IsString != 0 {
+		schema = "z.enum([" + strings.Join(values, ", ") + "])"
+	} else {
+		literals := make([]string, 0, len(values))
+
+		for _, v := range values {
+			literals = append(literals, "z.literal("+v+")")
+		}
+
+		schema = "z.union([" + strings.Join(literals, ", ") + "])"
+	}
+
+	return fmt.Sprintf(
+		"export type %s = %s;\n\nexport const %sSchema = %s;\n",
+		name, strings.Join(values, " | "), name, schema,
+	)
+}
+
+//nolint:cyclop
+func (g *zodGenerator) zodType(t types.Type) zodType {
+	t = types.Unalias(t)
+
+	switch t := t.(type) {
+	case *types.Named:
+		return g.named(t)
+
+	case *types.Basic:
+		switch {
+		case t.Info()&types.IsString != 0:
+			return zodType{schema: "z.string()", ts: "string", category: zodString}
+
+		case t.Info()&types.IsBoolean != 0:
+			return zodType{schema: "z.boolean()", ts: "boolean"}
+
+		case t.Info()&types.IsInteger != 0:
+			return zodType{schema: "z.number().int()", ts: "number", category: zodNumber}
+
+		case t.Info()&types.IsFloat != 0:
+			return zodType{schema: "z.number()", ts: "number", category: zodNumber}
+		}
+
+	case *types.Pointer:
+		elem := g.zodType(t.Elem()).orNull()
+		elem.nullable = true
+
+		return elem
+
+	case *types.Slice:
+		if basic, ok := t.Elem().(*types.Basic); ok && basic.Kind() == types.Byte {
+			// base64 encoded
+			return zodType{schema: "z.string().base64()", ts: "string", category: zodString, nullable: true}
+		}
+
+		elem := g.zodType(t.Elem()).orNull()
+
+		return zodType{
+			schema:   "z.array(" + elem.schema + ")",
+			ts:       arrayOf(elem.ts),
+			category: zodArray,
+			nullable: true,
+		}
+
+	case *types.Array:
+		elem := g.zodType(t.Elem()).orNull()
+
+		return zodType{
+			schema:   fmt.Sprintf("z.array(%s).length(%d)", elem.schema, t.Len()),
+			ts:       arrayOf(elem.ts),
+			category: zodArray,
+		}
+
+	case *types.Map:
+		elem := g.zodType(t.Elem()).orNull()
+
+		return zodType{
+			schema:   "z.record(z.string(), " + elem.schema + ")",
+			ts:       "Record<string, " + elem.ts + ">",
+			nullable: true,
+		}
+
+	case *types.Struct:
+		fields := g.fields(t)
+
+		ts := make([]string, 0, len(fields))
+		schema := make([]string, 0, len(fields))
+
+		for _, f := range fields {
+			ts = append(ts, f[0])
+			schema = append(schema, f[1])
+		}
+
+		return zodType{
+			schema: "z.object({ " + strings.Join(schema, ", ") + " })",
+			ts:     "{ " + strings.Join(ts, "; ") + " }",
+		}
+	}
+
+	return zodType{schema: "z.unknown()", ts: "unknown"}
+}
+
+func (g *zodGenerator) named(t *types.Named) zodType {
+	obj := t.Obj()
+
+	if obj.Pkg() != nil {
+		switch obj.Pkg().Path() + "." + obj.Name() {
+		// the default value of Defaulted is known at runtime only, so it is filled by the server
+		case requiredPkg + ".Custom", optionalPkg + ".Custom", optionalPkg + ".Defaulted":
+			base := g.zodType(t.TypeArgs().At(0))
+			base.unchecked = base.schema
+			base.schema, _ = g.checks(t.TypeArgs().At(1), base)
+			base.optional = obj.Pkg().Path() == optionalPkg
+
+			// null is a missing value, which is valid for optional types only
+			base.nullable = base.optional
+
+			return base
+
+		case requiredPkg + ".Refined", optionalPkg + ".Refined":
+			base := g.zodType(t.TypeArgs().At(0))
+			base.unchecked = base.schema
+			base.schema, _ = g.checks(t.TypeArgs().At(2), base)
+			base.optional = obj.Pkg().Path() == optionalPkg
+			base.nullable = base.optional
+
+			return base
+
+		case "time.Time":
+			return zodType{schema: "z.string().datetime({ offset: true })", ts: "string", category: zodDateTime}
+		}
+	}
+
+	mset := types.NewMethodSet(types.NewPointer(t))
+
+	switch {
+	case mset.Lookup(nil, "MarshalJSON") != nil:
+		log.Printf("%s implements json.Marshaler, using unknown", t)
+
+		return zodType{schema: "z.unknown()", ts: "unknown"}
+
+	case mset.Lookup(nil, "MarshalText") != nil:
+		return zodType{schema: "z.string()", ts: "string", category: zodString}
+	}
+
+	if t.TypeArgs().Len() > 0 {
+		// generic types are inlined
+		return g.zodType(t.Underlying())
+	}
+
+	switch t.Underlying().(type) {
+	case *types.Struct:
+		return g.declare(obj)
+
+	case *types.Basic:
+		if g.enum(obj, obj.Name()) != "" {
+			return g.declare(obj)
+		}
+	}
+
+	return g.zodType(t.Underlying())
+}
+
+// checks returns zod schema of the base type with checks of the validator v.
+// Transformers are mapped to zod transformations.
+//
+// It also reports whether the schema is chainable, i.e. checks of the base type (e.g. .min()) can be appended to it.
+// Unions and refinements are not chainable.
+//
+//nolint:cyclop
+func (g *zodGenerator) checks(v types.Type, base zodType) (string, bool) {
+	named, ok := types.Unalias(v).(*types.Named)
+	if !ok {
+		return base.schema, true
+	}
+
+	obj := named.Obj()
+	args := named.TypeArgs()
+
+	if obj.Pkg() != nil && obj.Pkg().Path() == validatePkg {
+		switch obj.Name() {
+		case "Any":
+			return base.schema, true
+
+		case "And":
+			return g.and(args.At(1), args.At(2), base)
+
+		case "Or":
+			left, _ := g.checks(args.At(1), base)
+			right, _ := g.checks(args.At(2), base)
+
+			return "z.union([" + left + ", " + right + "])", false
+
+		case "Not":
+			negated := base
+			if negated.unchecked != "" {
+				negated.schema = negated.unchecked
+			}
+
+			inner, _ := g.checks(args.At(1), negated)
+
+			return fmt.Sprintf("%s.refine((value) => !%s.safeParse(value).success)", base.schema, inner), false
+		}
+
+		check, ok := zodChecks[base.category][obj.Name()]
+		if !ok {
+			log.Printf("validator %s is not supported by zod generator, skipping", named)
+		}
+
+		return base.schema + check, chainable(check)
+	}
+
+	if obj.Pkg() != nil && obj.Pkg().Path() == transformPkg {
+		switch obj.Name() {
+		case "Pipe", "And":
+			left, ok := g.checks(args.At(1), base)
+			if ok {
+				base.schema = left
+
+				return g.checks(args.At(2), base)
+			}
+
+			// the order matters, so the right side is applied to the output of the left one
+			right, _ := g.checks(args.At(2), base)
+
+			return left + ".pipe(" + right + ")", false
+		}
+
+		transform, ok := zodTransforms[obj.Name()]
+		if !ok || base.category != zodString {
+			log.Printf("transformer %s is not supported by zod generator, skipping", named)
+		}
+
+		return base.schema + transform, true
+	}
+
+	directives := strings.Join(g.directives(obj), "")
+	if directives == "" {
+		log.Printf("validator %s has no %q directives, skipping", named, strings.TrimSpace(zodDirective))
+	}
+
+	return base.schema + directives, chainable(directives)
+}
+
+// and returns zod schema of the base type with checks of both validators.
+//
+// Chainable checks are applied first, so that the other ones are applied on top of them,
+// e.g. each branch of a union gets them. If neither side is chainable, they are intersected.
+func (g *zodGenerator) and(left, right types.Type, base zodType) (string, bool) {
+	if schema, ok := g.checks(left, base); ok {
+		base.schema = schema
+
+		return g.checks(right, base)
+	}
+
+	if schema, ok := g.checks(right, base); ok {
+		base.schema = schema
+
+		return g.checks(left, base)
+	}
+
+	leftSchema, _ := g.checks(left, base)
+	rightSchema, _ := g.checks(right, base)
+
+	return leftSchema + ".and(" + rightSchema + ")", false
+}
+
+// chainable reports whether checks of the base type can be appended after the given checks.
+func chainable(checks string) bool {
+	return !strings.Contains(checks, ".refine(") && !strings.Contains(checks, ".superRefine(") &&
+		!strings.Contains(checks, ".transform(") && !strings.Contains(checks, ".pipe(")
+}
+
+// directives returns zod checks from the doc comment of the validator type.
+func (g *zodGenerator) directives(obj *types.TypeName) []string {
+	pkg, ok := g.pkgs[obj.Pkg()]
+	if !ok {
+		return nil
+	}
+
+	var directives []string
+
+	for _, file := range pkg.Syntax {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, spec := range gen.Specs {
+				//nolint:forcetypeassert // type declarations contain type specs only
+				spec := spec.(*ast.TypeSpec)
+
+				if spec.Name.Name != obj.Name() {
+					continue
+				}
+
+				doc := spec.Doc
+				if doc == nil {
+					doc = gen.Doc
+				}
+
+				if doc == nil {
+					return nil
+				}
+
+				for _, c := range doc.List {
+					if directive, ok := strings.CutPrefix(c.Text, zodDirective); ok {
+						directives = append(directives, strings.TrimSpace(directive))
+					}
+				}
+
+				return directives
+			}
+		}
+	}
+
+	return nil
+}
+
+func derefType(t types.Type) types.Type {
+	if p, ok := t.(*types.Pointer); ok {
+		return p.Elem()
+	}
+
+	return t
+}
+
+func arrayOf(ts string) string {
+	if strings.ContainsAny(ts, " |") {
+		return "(" + ts + ")[]"
+	}
+
+	return ts + "[]"
+}
+
+// quote returns string as a TypeScript literal.
+func quote(s string) string {
+	//nolint:errchkjson // strings are always encoded
+	data, _ := json.Marshal(s)
+
+	return string(data)
+}
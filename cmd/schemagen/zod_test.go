@@ -0,0 +1,22 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/metafates/schema/internal/testutil"
+)
+
+func TestGenZod(t *testing.T) {
+	pkg := parsePackages("testdata/zod", ".")[0]
+
+	path := filepath.Join(t.TempDir(), "user.ts")
+
+	genZod(path, pkg, []string{"User"})
+
+	got, err := os.ReadFile(path)
+	testutil.NoError(t, err)
+
+	golden(t, "testdata/zod/user.ts.golden", got)
+}
@@ -0,0 +1,210 @@
+// Package config loads configuration from layered sources (files, environment variables, command-line flags)
+// into schema structs.
+package config
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+
+	"github.com/metafates/schema/internal/reflectwalk"
+	"github.com/metafates/schema/internal/schematype"
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/validate"
+)
+
+// Source is a configuration layer, e.g. file, environment variables or command-line flags.
+type Source interface {
+	// Name returns the name of this source, e.g. "config.json", "env" or "flags".
+	Name() string
+
+	// Load returns the values of this source for the fields of dst, which is a pointer to the configuration struct.
+	// Values of the missing keys should be omitted.
+	Load(dst any) ([]Value, error)
+}
+
+// Value is a value loaded from the [Source].
+type Value struct {
+	// Path is the path of the configuration field, e.g. ".Database.Host".
+	// Fields of embedded structs are promoted, e.g. ".Debug" instead of ".Base.Debug".
+	Path string
+
+	// Key is the key of the value in the source, e.g. "database.host" or "APP_DATABASE_HOST".
+	Key string
+
+	// Value is the value parsed into the field with [parse.Parse], strings are coerced.
+	Value any
+}
+
+// Origin describes where the configuration value came from.
+type Origin struct {
+	// Source is the name of the source, see [Source.Name].
+	Source string
+
+	// Key is the key of the value in the source, see [Value.Key].
+	Key string
+}
+
+// Error is an error of the configuration value.
+type Error struct {
+	// Origin is where the invalid value came from. It is zero for the missing values.
+	Origin Origin
+
+	Err error
+
+	path string
+}
+
+func (e Error) Error() string {
+	if e.Origin.Source == "" {
+		return "config: " + e.Err.Error()
+	}
+
+	return "config: " + e.Origin.Source + ": " + e.Origin.Key + ": " + e.Err.Error()
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Path returns the path of the configuration field which raised this error, e.g. ".Database.Host".
+func (e Error) Path() string {
+	return e.path
+}
+
+// SourceError is an error returned by [Source.Load].
+type SourceError struct {
+	Source string
+	Err    error
+}
+
+func (e SourceError) Error() string {
+	return "config: " + e.Source + ": " + e.Err.Error()
+}
+
+func (e SourceError) Unwrap() error {
+	return e.Err
+}
+
+// Loader loads configuration of type T from the sources.
+//
+// Loader is safe for concurrent use.
+type Loader[T any] struct {
+	sources []Source
+
+	mu      sync.RWMutex
+	value   T
+	origins map[string]Origin
+}
+
+// NewLoader returns a new [Loader] which loads configuration from the given sources.
+// Values of the latter sources take precedence, e.g. flags should be passed after environment variables and files.
+func NewLoader[T any](sources ...Source) *Loader[T] {
+	return &Loader[T]{sources: sources}
+}
+
+// Load loads values from all sources, merges them, parses and validates the configuration.
+//
+// Values of the same field are overridden by the latter sources as a whole, e.g. slices are not merged.
+// All missing and invalid values are reported at once as [validate.Errors] of [Error].
+//
+// Successfully loaded configuration is available with [Loader.Get].
+// On failure the last loaded configuration is kept, so that Load can be called
+// when the configuration changes, e.g. from a file watcher callback.
+func (l *Loader[T]) Load() error {
+	var dst T
+
+	values := make(map[string]any)
+	origins := make(map[string]Origin)
+
+	for _, source := range l.sources {
+		loaded, err := source.Load(&dst)
+		if err != nil {
+			return SourceError{Source: source.Name(), Err: err}
+		}
+
+		for _, v := range loaded {
+			schematype.Set(values, v.Path, v.Value)
+
+			origins[v.Path] = Origin{Source: source.Name(), Key: v.Key}
+		}
+	}
+
+	err := parse.Parse(values, &dst,
+		parse.WithCoercion(),
+		parse.WithAllErrors(),
+		parse.WithFieldTag(""),
+		parse.WithFieldMatching(parse.MatchExact),
+		parse.WithRenameFunc(func(s string) string { return s }),
+	)
+	if err != nil {
+		return originErrors(err, origins)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.value = dst
+	l.origins = origins
+
+	return nil
+}
+
+// Get returns the last successfully loaded configuration.
+// It returns zero value if configuration was never loaded.
+func (l *Loader[T]) Get() T {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.value
+}
+
+// Origin returns the origin of the value of the field at the given path (e.g. ".Database.Host")
+// in the last successfully loaded configuration.
+// It reports false if the value is missing from all sources.
+func (l *Loader[T]) Origin(path string) (Origin, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	origin, ok := l.origins[path]
+
+	return origin, ok
+}
+
+// originErrors wraps errors in err with origins of the invalid values.
+func originErrors(err error, origins map[string]Origin) error {
+	var errs validate.Errors
+
+	if !errors.As(err, &errs) {
+		var pathErr validate.PathError
+
+		if !errors.As(err, &pathErr) {
+			return err
+		}
+
+		errs = validate.Errors{pathErr}
+	}
+
+	result := make(validate.Errors, 0, len(errs))
+
+	for _, err := range errs {
+		configErr := Error{Err: err, path: err.Path()}
+
+		for path, origin := range origins {
+			if reflectwalk.IsNested(err.Path(), path) {
+				configErr.Origin = origin
+
+				break
+			}
+		}
+
+		result = append(result, configErr)
+	}
+
+	return result
+}
+
+// leaves returns leaf fields of the configuration struct dst, see [schematype.Leaves].
+func leaves(dst any, tag string) []schematype.Leaf {
+	return schematype.Leaves(reflect.TypeOf(dst), tag)
+}
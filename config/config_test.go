@@ -0,0 +1,159 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	schemaenv "github.com/metafates/schema/encoding/env"
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+	"github.com/metafates/schema/validate"
+)
+
+type Server struct {
+	Name required.NonZero[string]
+}
+
+type Database struct {
+	Host     required.NonZero[string]
+	MaxConns optional.Positive[int] `json:"max_conns"`
+}
+
+type Config struct {
+	Debug    bool
+	Port     required.Positive[int]
+	Timeout  time.Duration
+	Tags     []string
+	Servers  []Server
+	Database Database `json:"db" env:"DB" flag:"db"`
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.json")
+
+	testutil.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestLoader(t *testing.T) {
+	path := writeFile(t, `{
+		"port": 80,
+		"timeout": "1m",
+		"servers": [{"name": "a"}],
+		"db": {"host": "file", "max_conns": 10}
+	}`)
+
+	loader := NewLoader[Config](
+		JSONFile(path),
+		Env(schemaenv.WithPrefix("APP"), schemaenv.WithEnv(map[string]string{
+			"APP_PORT":    "8080",
+			"APP_DB_HOST": "env",
+		})),
+		Flags([]string{"-debug", "-db.host", "flag", "-tags", "a", "-tags", "b"}),
+	)
+
+	testutil.NoError(t, loader.Load())
+
+	cfg := loader.Get()
+
+	testutil.Equal(t, true, cfg.Debug)
+	testutil.Equal(t, 8080, cfg.Port.Get())
+	testutil.Equal(t, time.Minute, cfg.Timeout)
+	testutil.DeepEqual(t, []string{"a", "b"}, cfg.Tags)
+	testutil.Equal(t, "a", cfg.Servers[0].Name.Get())
+	testutil.Equal(t, "flag", cfg.Database.Host.Get())
+	testutil.Equal(t, 10, cfg.Database.MaxConns.Must())
+
+	for field, want := range map[string]Origin{
+		".Port":              {Source: "env", Key: "APP_PORT"},
+		".Timeout":           {Source: path, Key: "timeout"},
+		".Database.Host":     {Source: "flags", Key: "-db.host"},
+		".Database.MaxConns": {Source: path, Key: "db.max_conns"},
+	} {
+		origin, ok := loader.Origin(field)
+		testutil.Equal(t, true, ok)
+		testutil.Equal(t, want, origin)
+	}
+
+	_, ok := loader.Origin(".Debug.Missing")
+	testutil.Equal(t, false, ok)
+}
+
+func TestLoader_keys(t *testing.T) {
+	path := writeFile(t, `{
+		"port": 1,
+		"Port": 2,
+		"time_out": "1s",
+		"timeout": "2s",
+		"TIMEOUT": "3s",
+		"db": {"host": "file", "max_conns": 10, "MaxConns": 20}
+	}`)
+
+	// repeat as map iteration order is random
+	for range 10 {
+		loader := NewLoader[Config](JSONFile(path))
+
+		testutil.NoError(t, loader.Load())
+
+		cfg := loader.Get()
+
+		testutil.Equal(t, 2, cfg.Port.Get())
+		testutil.Equal(t, 3*time.Second, cfg.Timeout)
+		testutil.Equal(t, 10, cfg.Database.MaxConns.Must())
+	}
+}
+
+func TestLoader_errors(t *testing.T) {
+	path := writeFile(t, `{"port": -1, "db": {"max_conns": "many"}}`)
+
+	loader := NewLoader[Config](JSONFile(path))
+
+	var errs validate.Errors
+
+	testutil.Equal(t, true, errors.As(loader.Load(), &errs))
+	testutil.Equal(t, 3, len(errs))
+
+	origins := make(map[string]Origin)
+
+	for _, err := range errs {
+		var configErr Error
+
+		testutil.Equal(t, true, errors.As(err, &configErr))
+
+		origins[configErr.Path()] = configErr.Origin
+	}
+
+	testutil.DeepEqual(t, map[string]Origin{
+		".Port":              {Source: path, Key: "port"},
+		".Database.Host":     {},
+		".Database.MaxConns": {Source: path, Key: "db.max_conns"},
+	}, origins)
+
+	var sourceErr SourceError
+
+	testutil.Equal(t, true, errors.As(NewLoader[Config](JSONFile(path+".missing")).Load(), &sourceErr))
+}
+
+func TestLoader_reload(t *testing.T) {
+	path := writeFile(t, `{"port": 80, "db": {"host": "localhost"}}`)
+
+	loader := NewLoader[Config](JSONFile(path))
+
+	testutil.NoError(t, loader.Load())
+	testutil.Equal(t, 80, loader.Get().Port.Get())
+
+	testutil.NoError(t, os.WriteFile(path, []byte(`{"port": -1, "db": {"host": "localhost"}}`), 0o600))
+	testutil.Error(t, loader.Load())
+	testutil.Equal(t, 80, loader.Get().Port.Get())
+
+	testutil.NoError(t, os.WriteFile(path, []byte(`{"port": 81, "db": {"host": "localhost"}}`), 0o600))
+	testutil.NoError(t, loader.Load())
+	testutil.Equal(t, 81, loader.Get().Port.Get())
+}
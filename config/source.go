@@ -0,0 +1,290 @@
+package config
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"reflect"
+	"strings"
+
+	schemaenv "github.com/metafates/schema/encoding/env"
+	"github.com/metafates/schema/internal/naming"
+	"github.com/metafates/schema/internal/schematype"
+)
+
+// File returns a [Source] which reads the file at path and decodes it with unmarshal function,
+// e.g. [json.Unmarshal] or yaml.Unmarshal.
+//
+// Keys are matched with the fields by `json` tag or by Go name regardless of case, underscores and dashes,
+// e.g. "max_conns" key matches MaxConns field. Exact matches take precedence, otherwise the least matching key is used.
+func File(path string, unmarshal func(data []byte, v any) error) Source {
+	return fileSource{path: path, unmarshal: unmarshal}
+}
+
+// JSONFile returns a [Source] which reads JSON file at path. See [File].
+func JSONFile(path string) Source {
+	return File(path, json.Unmarshal)
+}
+
+type fileSource struct {
+	path      string
+	unmarshal func(data []byte, v any) error
+}
+
+func (s fileSource) Name() string { return s.path }
+
+func (s fileSource) Load(dst any) ([]Value, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, err
+	}
+
+	var tree map[string]any
+
+	if err := s.unmarshal(data, &tree); err != nil {
+		return nil, err
+	}
+
+	var values []Value
+
+leaves:
+	for _, leaf := range leaves(dst, "json") {
+		keys := make([]string, 0, len(leaf.Fields))
+
+		var value any = tree
+
+		for _, field := range leaf.Fields {
+			m, ok := value.(map[string]any)
+			if !ok {
+				continue leaves
+			}
+
+			key, ok := fileKey(m, field)
+			if !ok {
+				continue leaves
+			}
+
+			keys = append(keys, key)
+			value = m[key]
+		}
+
+		if value == nil {
+			continue
+		}
+
+		values = append(values, Value{
+			Path:  leaf.Path,
+			Key:   strings.Join(keys, "."),
+			Value: rekey(value, leaf.Type),
+		})
+	}
+
+	return values, nil
+}
+
+// fileKey returns the key of m matching the field.
+// Exact matches of the tag and the field name take precedence,
+// otherwise the least of the keys matching regardless of case is returned, so that the result does not depend on map order.
+func fileKey(m map[string]any, field reflect.StructField) (string, bool) {
+	if tag, _, _ := strings.Cut(field.Tag.Get("json"), ","); tag != "" {
+		if _, ok := m[tag]; ok {
+			return tag, true
+		}
+	}
+
+	if _, ok := m[field.Name]; ok {
+		return field.Name, true
+	}
+
+	var (
+		match string
+		found bool
+	)
+
+	for key := range m {
+		if fold(key) == fold(field.Name) && (!found || key < match) {
+			match, found = key, true
+		}
+	}
+
+	return match, found
+}
+
+// rekey renames keys of the decoded value v to the names of dst fields, so that it can be parsed into dst.
+func rekey(v any, dst reflect.Type) any {
+	dst = schematype.Target(dst)
+
+	if dst == nil {
+		return v
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+
+		switch dst.Kind() {
+		case reflect.Struct:
+			for _, field := range reflect.VisibleFields(dst) {
+				if !field.IsExported() {
+					continue
+				}
+
+				if key, ok := fileKey(v, field); ok {
+					result[field.Name] = rekey(v[key], field.Type)
+				}
+			}
+
+		case reflect.Map:
+			for key, value := range v {
+				result[key] = rekey(value, dst.Elem())
+			}
+
+		default:
+			return v
+		}
+
+		return result
+
+	case []any:
+		if dst.Kind() != reflect.Slice && dst.Kind() != reflect.Array {
+			return v
+		}
+
+		result := make([]any, 0, len(v))
+
+		for _, value := range v {
+			result = append(result, rekey(value, dst.Elem()))
+		}
+
+		return result
+
+	default:
+		return v
+	}
+}
+
+func fold(name string) string {
+	return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+}
+
+// Env returns a [Source] which reads environment variables with [schemaenv.Lookup].
+func Env(options ...schemaenv.Option) Source {
+	return envSource{options: options}
+}
+
+type envSource struct {
+	options []schemaenv.Option
+}
+
+func (envSource) Name() string { return "env" }
+
+func (s envSource) Load(dst any) ([]Value, error) {
+	variables := schemaenv.Lookup(dst, s.options...)
+
+	values := make([]Value, 0, len(variables))
+
+	for _, v := range variables {
+		values = append(values, Value{Path: v.Path, Key: v.Name, Value: v.Value})
+	}
+
+	return values, nil
+}
+
+// Flags returns a [Source] which parses command-line arguments, e.g. os.Args[1:].
+//
+// Flags are named after the fields in kebab-case joined by dots, unless `flag` tag is set
+// (`flag:"-"` skips the field), e.g. Database.MaxConns field is set with "-database.max-conns" flag.
+// Boolean fields are set without value, e.g. "-debug". Slices are set by repeating the flag.
+// Only the flags present in args are loaded.
+func Flags(args []string) Source {
+	return flagSource{args: args}
+}
+
+type flagSource struct {
+	args []string
+}
+
+func (flagSource) Name() string { return "flags" }
+
+func (s flagSource) Load(dst any) ([]Value, error) {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+
+	flags := make(map[string]*flagValue)
+
+	for _, leaf := range leaves(dst, "flag") {
+		names := make([]string, 0, len(leaf.Fields))
+
+		for _, field := range leaf.Fields {
+			names = append(names, flagName(field))
+		}
+
+		name := strings.Join(names, ".")
+
+		value := &flagValue{path: leaf.Path, dst: leaf.Type}
+
+		fs.Var(value, name, "")
+
+		flags[name] = value
+	}
+
+	if err := fs.Parse(s.args); err != nil {
+		return nil, err
+	}
+
+	var values []Value
+
+	fs.Visit(func(f *flag.Flag) {
+		value := flags[f.Name]
+
+		values = append(values, Value{Path: value.path, Key: "-" + f.Name, Value: value.value()})
+	})
+
+	return values, nil
+}
+
+func flagName(field reflect.StructField) string {
+	if tag, _, _ := strings.Cut(field.Tag.Get("flag"), ","); tag != "" {
+		return tag
+	}
+
+	return naming.Kebab(field.Name)
+}
+
+// flagValue is a [flag.Value] which collects values of the flag.
+type flagValue struct {
+	path   string
+	dst    reflect.Type
+	values []string
+}
+
+func (v *flagValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return strings.Join(v.values, ",")
+}
+
+func (v *flagValue) Set(s string) error {
+	v.values = append(v.values, s)
+
+	return nil
+}
+
+func (v *flagValue) IsBoolFlag() bool {
+	return v.dst.Kind() == reflect.Bool
+}
+
+func (v *flagValue) value() any {
+	if (v.dst.Kind() == reflect.Slice || v.dst.Kind() == reflect.Array) && v.dst.Elem().Kind() != reflect.Uint8 {
+		values := make([]any, 0, len(v.values))
+
+		for _, s := range v.values {
+			values = append(values, s)
+		}
+
+		return values
+	}
+
+	return v.values[len(v.values)-1]
+}
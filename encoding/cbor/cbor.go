@@ -0,0 +1,71 @@
+// Package schemacbor wraps CBOR decoding functions and calls validation after unmarshalling.
+//
+// Fields of required and optional types are decoded into their underlying types directly,
+// so that integer and float widths are preserved. Nil values are missing.
+//
+// Decoding options are set with [SetDecMode].
+package schemacbor
+
+import (
+	"io"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/metafates/schema/internal/cborcodec"
+	"github.com/metafates/schema/validate"
+)
+
+// Decoder wraps [cbor.Decoder] with validation step after decoding.
+//
+// See [cbor.Decoder] documentation.
+type Decoder struct {
+	*cbor.Decoder
+}
+
+// SetDecMode sets the decoding mode used by [Unmarshal], [NewDecoder] and required and optional types.
+// Nil resets it to the default mode.
+//
+// The mode applies to required and optional types even if they are decoded by other means, e.g. [cbor.DecMode.Unmarshal],
+// since [cbor.Unmarshaler] does not receive the mode of the caller.
+//
+// It is meant to be called once on program initialization.
+func SetDecMode(dm cbor.DecMode) {
+	cborcodec.SetDecMode(dm)
+}
+
+// NewDecoder returns a new decoder that reads from r with the mode set by [SetDecMode].
+//
+// See [cbor.NewDecoder] documentation.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{cborcodec.DecMode().NewDecoder(r)}
+}
+
+// Decode wraps [cbor.Decoder.Decode] and calls [validate.Validate] afterwards.
+//
+// See also [Unmarshal].
+func (dec *Decoder) Decode(v any) error {
+	if err := dec.Decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Unmarshal wraps [cbor.Unmarshal] with the mode set by [SetDecMode] and calls [validate.Validate] afterwards.
+//
+// See also [Decoder.Decode].
+func Unmarshal(data []byte, v any) error {
+	if err := cborcodec.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(v); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package schemacbor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+)
+
+func TestCBOR(t *testing.T) {
+	type Mock struct {
+		Name required.NonZero[string] `cbor:"name"`
+		Age  optional.Positive[int8]  `cbor:"age"`
+	}
+
+	valid, err := cbor.Marshal(map[string]any{"name": "john", "age": 42})
+	testutil.NoError(t, err)
+
+	invalid, err := cbor.Marshal(map[string]any{"name": "john", "age": -2})
+	testutil.NoError(t, err)
+
+	var mock Mock
+
+	testutil.NoError(t, Unmarshal(valid, &mock))
+	testutil.Equal(t, int8(42), mock.Age.Must())
+	testutil.Error(t, Unmarshal(invalid, &mock))
+
+	testutil.NoError(t, NewDecoder(bytes.NewReader(valid)).Decode(&mock))
+	testutil.Error(t, NewDecoder(bytes.NewReader(invalid)).Decode(&mock))
+}
+
+func TestSetDecMode(t *testing.T) {
+	type Mock struct {
+		Scores required.Any[map[string]int] `cbor:"scores"`
+	}
+
+	// map with duplicate keys nested in required type
+	data := []byte{0xa1, 0x66, 's', 'c', 'o', 'r', 'e', 's', 0xa2, 0x61, 'a', 0x01, 0x61, 'a', 0x02}
+
+	var mock Mock
+
+	testutil.NoError(t, Unmarshal(data, &mock))
+
+	dm, err := cbor.DecOptions{DupMapKey: cbor.DupMapKeyEnforcedAPF}.DecMode()
+	testutil.NoError(t, err)
+
+	SetDecMode(dm)
+	t.Cleanup(func() { SetDecMode(nil) })
+
+	testutil.Error(t, Unmarshal(data, &mock))
+	testutil.Error(t, NewDecoder(bytes.NewReader(data)).Decode(&mock))
+
+	// mode of the caller does not reach required and optional types, so the mode set is used
+	defaultMode, err := cbor.DecOptions{}.DecMode()
+	testutil.NoError(t, err)
+
+	testutil.Error(t, defaultMode.Unmarshal(data, &mock))
+}
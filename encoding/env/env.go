@@ -0,0 +1,309 @@
+// Package schemaenv fills schema structs from environment variables.
+package schemaenv
+
+import (
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/metafates/schema/internal/naming"
+	"github.com/metafates/schema/internal/schematype"
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/validate"
+)
+
+// Error is an error of the environment variable with the given name.
+type Error struct {
+	// Name is the name of the environment variable, e.g. "APP_DATABASE_HOST".
+	Name string
+
+	Err error
+}
+
+func (e Error) Error() string {
+	return "env: " + e.Name + ": " + e.Err.Error()
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Path returns the name of the environment variable which raised this error.
+// It makes [Error] a [validate.PathError].
+func (e Error) Path() string {
+	return e.Name
+}
+
+type config struct {
+	prefix    string
+	separator string
+	env       map[string]string
+	options   []parse.Option
+}
+
+func (cfg *config) lookup(name string) (string, bool) {
+	if cfg.env != nil {
+		value, ok := cfg.env[name]
+
+		return value, ok
+	}
+
+	return os.LookupEnv(name)
+}
+
+// Option modifies the parsing of environment variables.
+type Option func(cfg *config)
+
+// WithPrefix is an option that will prefix names of all variables, e.g. "APP" prefix and Port field result in "APP_PORT" variable.
+func WithPrefix(prefix string) Option {
+	return func(cfg *config) {
+		cfg.prefix = prefix
+	}
+}
+
+// WithSeparator is an option that will split values of slices by the given separator.
+// Default separator is ",".
+func WithSeparator(separator string) Option {
+	return func(cfg *config) {
+		cfg.separator = separator
+	}
+}
+
+// WithEnv is an option that will read variables from the given map instead of the process environment.
+// It is useful for tests.
+func WithEnv(env map[string]string) Option {
+	return func(cfg *config) {
+		cfg.env = env
+	}
+}
+
+// WithParseOptions is an option that will pass the given options to [parse.Parse], e.g. [parse.WithConverter].
+func WithParseOptions(options ...parse.Option) Option {
+	return func(cfg *config) {
+		cfg.options = append(cfg.options, options...)
+	}
+}
+
+// Variable is a set environment variable of the dst field.
+type Variable struct {
+	// Name is the name of the variable, e.g. "APP_DATABASE_HOST".
+	Name string
+
+	// Path is the path of the dst field, e.g. ".Database.Host".
+	// Fields of embedded structs are promoted, e.g. ".Debug" instead of ".Base.Debug".
+	Path string
+
+	// Value is the value of the variable prepared for parsing, e.g. slices are split by the separator.
+	Value any
+}
+
+// Lookup returns the set environment variables of dst fields.
+// See [Parse] for naming rules.
+func Lookup(dst any, options ...Option) []Variable {
+	cfg := newConfig(options)
+
+	return cfg.variables(reflect.TypeOf(dst))
+}
+
+// Parse fills dst from environment variables and validates it.
+//
+// Variables are named after dst fields in SCREAMING_SNAKE_CASE, unless `env` tag is set
+// (`env:"-"` skips the field). Fields of nested structs are prefixed with the name of the parent field,
+// e.g. Database.MaxConns field is filled from "DATABASE_MAX_CONNS" variable, embedded structs are not prefixed.
+//   - Values are coerced to the field types, e.g. durations from "1m30s", see [parse.WithCoercion].
+//   - Slices are split by the separator, see [WithSeparator].
+//   - Unset and empty (unless the field is a string) variables are missing, so that required fields fail.
+//
+// All missing and invalid variables are reported at once as [validate.Errors] of [Error].
+func Parse(dst any, options ...Option) error {
+	cfg := newConfig(options)
+
+	dstType := reflect.TypeOf(dst)
+
+	values := make(map[string]any)
+
+	for _, v := range cfg.variables(dstType) {
+		schematype.Set(values, v.Path, v.Value)
+	}
+
+	parseOptions := append([]parse.Option{parse.WithCoercion()}, cfg.options...)
+
+	// fields are already named after dst ones
+	parseOptions = append(parseOptions,
+		parse.WithAllErrors(),
+		parse.WithFieldTag(""),
+		parse.WithFieldMatching(parse.MatchExact),
+		parse.WithRenameFunc(func(s string) string { return s }),
+	)
+
+	return cfg.nameErrors(parse.Parse(values, dst, parseOptions...), dstType)
+}
+
+func newConfig(options []Option) config {
+	cfg := config{separator: ","}
+
+	for _, apply := range options {
+		apply(&cfg)
+	}
+
+	return cfg
+}
+
+// variables returns the set variables of dst struct fields.
+func (cfg *config) variables(dst reflect.Type) []Variable {
+	var result []Variable
+
+	for _, leaf := range schematype.Leaves(dst, "env") {
+		name := cfg.prefix
+
+		for _, field := range leaf.Fields {
+			name = join(name, fieldName(field))
+		}
+
+		value, ok := cfg.lookup(name)
+		if !ok {
+			continue
+		}
+
+		if v := cfg.value(value, leaf.Type); v != nil {
+			result = append(result, Variable{Name: name, Path: leaf.Path, Value: v})
+		}
+	}
+
+	return result
+}
+
+// value converts variable value into a value which can be parsed into dst type.
+// It returns nil if the value is missing.
+func (cfg *config) value(value string, dst reflect.Type) any {
+	if dst.Kind() == reflect.Interface {
+		return value
+	}
+
+	if (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array) && dst.Elem().Kind() != reflect.Uint8 {
+		if value == "" {
+			return nil
+		}
+
+		parts := strings.Split(value, cfg.separator)
+
+		result := make([]any, 0, len(parts))
+
+		for _, part := range parts {
+			result = append(result, cfg.value(strings.TrimSpace(part), schematype.Target(dst.Elem())))
+		}
+
+		return result
+	}
+
+	if value == "" && dst.Kind() != reflect.String {
+		return nil
+	}
+
+	return value
+}
+
+// nameErrors replaces paths of Go fields in err with the variable names.
+func (cfg *config) nameErrors(err error, dst reflect.Type) error {
+	var errs validate.Errors
+
+	if errors.As(err, &errs) {
+		result := make(validate.Errors, 0, len(errs))
+
+		for _, err := range errs {
+			result = append(result, Error{Name: cfg.name(dst, err.Path()), Err: err})
+		}
+
+		return result
+	}
+
+	var pathErr validate.PathError
+
+	if errors.As(err, &pathErr) && pathErr.Path() != "" {
+		return validate.Errors{Error{Name: cfg.name(dst, pathErr.Path()), Err: err}}
+	}
+
+	return err
+}
+
+// name returns the name of the variable of the value at the path of dst, e.g. ".Database.Host" is converted to "DATABASE_HOST".
+// Indexes of slice elements are dropped, since the slice is filled from a single variable.
+func (cfg *config) name(dst reflect.Type, path string) string {
+	name := cfg.prefix
+
+	for path != "" {
+		dst = schematype.Target(dst)
+
+		switch path[0] {
+		case '.':
+			segment := path[1:]
+
+			end := strings.IndexAny(segment, ".[")
+			if end < 0 {
+				end = len(segment)
+			}
+
+			segment, path = segment[:end], segment[end:]
+
+			if dst == nil || dst.Kind() != reflect.Struct {
+				name = join(name, screamingSnakeCase(segment))
+				dst = nil
+
+				continue
+			}
+
+			field, ok := dst.FieldByName(segment)
+			if !ok {
+				name = join(name, screamingSnakeCase(segment))
+				dst = nil
+
+				continue
+			}
+
+			name = join(name, fieldName(field))
+			dst = field.Type
+
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return name
+			}
+
+			path = path[end+1:]
+
+			if dst != nil && (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array || dst.Kind() == reflect.Map) {
+				dst = dst.Elem()
+			} else {
+				dst = nil
+			}
+
+		default:
+			return name
+		}
+	}
+
+	return name
+}
+
+// fieldName returns the variable name of the field without prefix.
+func fieldName(field reflect.StructField) string {
+	if tag, _, _ := strings.Cut(field.Tag.Get("env"), ","); tag != "" && tag != "-" {
+		return tag
+	}
+
+	return screamingSnakeCase(field.Name)
+}
+
+func join(prefix, name string) string {
+	if prefix == "" {
+		return name
+	}
+
+	return prefix + "_" + name
+}
+
+// screamingSnakeCase converts Go name to SCREAMING_SNAKE_CASE, e.g. "HTTPServerPort" is converted to "HTTP_SERVER_PORT".
+func screamingSnakeCase(name string) string {
+	return strings.ToUpper(naming.Snake(name))
+}
@@ -0,0 +1,109 @@
+package schemaenv
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+	"github.com/metafates/schema/validate"
+)
+
+type Database struct {
+	Host     required.NonZero[string]
+	MaxConns optional.Positive[int]
+}
+
+type Base struct {
+	Debug bool
+}
+
+type Config struct {
+	Base
+
+	Port     required.Positive[int]
+	Timeout  time.Duration
+	Hosts    []string
+	Ports    []int
+	APIKey   optional.Any[string] `env:"SECRET_KEY"`
+	Database Database             `env:"DB"`
+	Ignored  string               `env:"-"`
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		var cfg Config
+
+		testutil.NoError(t, Parse(&cfg, WithPrefix("APP"), WithEnv(map[string]string{
+			"APP_DEBUG":        "true",
+			"APP_PORT":         "8080",
+			"APP_TIMEOUT":      "1m30s",
+			"APP_HOSTS":        "a, b",
+			"APP_PORTS":        "1;2",
+			"APP_SECRET_KEY":   "secret",
+			"APP_DB_HOST":      "localhost",
+			"APP_DB_MAX_CONNS": "",
+			"APP_IGNORED":      "ignored",
+		}), WithSeparator(";")))
+
+		testutil.Equal(t, true, cfg.Debug)
+		testutil.Equal(t, 8080, cfg.Port.Get())
+		testutil.Equal(t, 90*time.Second, cfg.Timeout)
+		testutil.DeepEqual(t, []string{"a, b"}, cfg.Hosts)
+		testutil.DeepEqual(t, []int{1, 2}, cfg.Ports)
+		testutil.Equal(t, "secret", cfg.APIKey.Must())
+		testutil.Equal(t, "localhost", cfg.Database.Host.Get())
+		testutil.Equal(t, false, cfg.Database.MaxConns.HasValue())
+		testutil.Equal(t, "", cfg.Ignored)
+	})
+
+	t.Run("process environment", func(t *testing.T) {
+		t.Setenv("PORT", "80")
+		t.Setenv("DB_HOST", "localhost")
+		t.Setenv("HOSTS", "a,b")
+
+		var cfg Config
+
+		testutil.NoError(t, Parse(&cfg))
+		testutil.Equal(t, 80, cfg.Port.Get())
+		testutil.DeepEqual(t, []string{"a", "b"}, cfg.Hosts)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var errs validate.Errors
+
+		err := Parse(new(Config), WithPrefix("APP"), WithEnv(map[string]string{
+			"APP_PORT":         "-1",
+			"APP_PORTS":        "1,x",
+			"APP_DB_MAX_CONNS": "many",
+		}))
+
+		testutil.Equal(t, true, errors.As(err, &errs))
+
+		names := make([]string, 0, len(errs))
+
+		for _, err := range errs {
+			names = append(names, err.Path())
+		}
+
+		slices.Sort(names)
+
+		testutil.DeepEqual(t, []string{"APP_DB_HOST", "APP_DB_MAX_CONNS", "APP_PORT", "APP_PORTS"}, names)
+	})
+}
+
+func TestScreamingSnakeCase(t *testing.T) {
+	for name, want := range map[string]string{
+		"Port":           "PORT",
+		"MaxConns":       "MAX_CONNS",
+		"UserID":         "USER_ID",
+		"HTTPServerPort": "HTTP_SERVER_PORT",
+		"V2Enabled":      "V2_ENABLED",
+		"APIKey":         "API_KEY",
+	} {
+		testutil.Equal(t, want, screamingSnakeCase(name))
+	}
+}
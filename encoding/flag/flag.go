@@ -0,0 +1,247 @@
+// Package schemaflag binds fields of schema structs to command-line flags.
+package schemaflag
+
+import (
+	"errors"
+	"flag"
+	"reflect"
+	"strings"
+
+	"github.com/metafates/schema/internal/naming"
+	"github.com/metafates/schema/internal/reflectwalk"
+	"github.com/metafates/schema/internal/schematype"
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/validate"
+)
+
+// Error is an error of the flag with the given name.
+type Error struct {
+	// Flag is the name of the flag without dash, e.g. "database.host".
+	Flag string
+
+	Err error
+}
+
+func (e Error) Error() string {
+	return "flag: -" + e.Flag + ": " + e.Err.Error()
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Path returns the name of the flag which raised this error.
+// It makes [Error] a [validate.PathError].
+func (e Error) Path() string {
+	return e.Flag
+}
+
+// Flags are flags of the schema struct fields registered with [Register].
+type Flags struct {
+	dst any
+
+	// names maps paths of the fields to the flag names
+	names map[string]string
+}
+
+// Register defines a flag on fs for every field of the schema struct dst, which must be a pointer.
+//
+// Flags are named after the fields in kebab-case joined by dots, unless `flag` tag is set
+// (`flag:"-"` skips the field), e.g. Database.MaxConns field is bound to "-database.max-conns" flag.
+// Fields of embedded structs are promoted and nil pointers to nested structs are allocated.
+// Usage is taken from `usage` tag.
+//
+// Fields implementing [flag.Value] (e.g. required.Custom and optional.Custom) are set with their Set method,
+// other fields are converted from strings (see [parse.WithCoercion]). Boolean fields are set without value, e.g. "-debug",
+// slices are set by repeating the flag.
+//
+// Flag values are not validated, call [Flags.Validate] after parsing flags.
+func Register(fs *flag.FlagSet, dst any) *Flags {
+	flags := &Flags{dst: dst, names: make(map[string]string)}
+
+	root := reflect.ValueOf(dst)
+
+	for _, leaf := range schematype.Leaves(root.Type(), "flag") {
+		field, ok := fieldByPath(root, leaf.Path)
+		if !ok {
+			continue
+		}
+
+		names := make([]string, 0, len(leaf.Fields))
+
+		for _, f := range leaf.Fields {
+			names = append(names, name(f))
+		}
+
+		flagName := strings.Join(names, ".")
+
+		fs.Var(&Value{v: field}, flagName, leaf.Fields[len(leaf.Fields)-1].Tag.Get("usage"))
+
+		flags.names[leaf.Path] = flagName
+	}
+
+	return flags
+}
+
+// Validate validates the registered schema struct.
+// All invalid and missing values are reported at once as [validate.Errors] of [Error].
+func (f *Flags) Validate() error {
+	err := validate.ValidateAll(f.dst)
+
+	var errs validate.Errors
+
+	if !errors.As(err, &errs) {
+		return err
+	}
+
+	result := make(validate.Errors, 0, len(errs))
+
+	for _, err := range errs {
+		result = append(result, Error{Flag: f.name(err.Path()), Err: err})
+	}
+
+	return result
+}
+
+// name returns the name of the flag the value at path is set with.
+func (f *Flags) name(path string) string {
+	for fieldPath, name := range f.names {
+		if reflectwalk.IsNested(path, fieldPath) {
+			return name
+		}
+	}
+
+	return strings.TrimPrefix(path, ".")
+}
+
+// Parse registers fields of dst on fs (see [Register]), parses args and validates dst.
+func Parse(fs *flag.FlagSet, dst any, args []string) error {
+	flags := Register(fs, dst)
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	return flags.Validate()
+}
+
+// Value is a [flag.Value] and [flag.Getter] of the struct field.
+type Value struct {
+	v reflect.Value
+
+	// set reports whether Set was called, so that default slice values are replaced instead of appended to
+	set bool
+}
+
+var (
+	_ flag.Getter = (*Value)(nil)
+
+	flagValueType = reflect.TypeFor[flag.Value]()
+)
+
+// Set implements the [flag.Value] interface.
+func (v *Value) Set(s string) error {
+	if v.v.Addr().Type().Implements(flagValueType) {
+		//nolint:forcetypeassert // checked above
+		return v.v.Addr().Interface().(flag.Value).Set(s)
+	}
+
+	t := v.v.Type()
+
+	if t.Kind() == reflect.Slice && t.Elem().Kind() != reflect.Uint8 {
+		elem := reflect.New(t.Elem())
+
+		if err := parse.Parse(s, elem.Interface(), parse.WithCoercion()); err != nil {
+			return err
+		}
+
+		if !v.set {
+			v.v.Set(reflect.MakeSlice(t, 0, 1))
+		}
+
+		v.v.Set(reflect.Append(v.v, elem.Elem()))
+		v.set = true
+
+		return nil
+	}
+
+	value := reflect.New(t)
+
+	if err := parse.Parse(s, value.Interface(), parse.WithCoercion()); err != nil {
+		return err
+	}
+
+	v.v.Set(value.Elem())
+	v.set = true
+
+	return nil
+}
+
+// String implements the [flag.Value] interface.
+func (v *Value) String() string {
+	if v == nil || !v.v.IsValid() {
+		return ""
+	}
+
+	if stringer, ok := v.v.Addr().Interface().(flag.Value); ok {
+		return stringer.String()
+	}
+
+	if v.v.IsZero() {
+		return ""
+	}
+
+	var s string
+
+	if err := parse.Parse(v.v.Interface(), &s, parse.WithCoercion()); err != nil {
+		return ""
+	}
+
+	return s
+}
+
+// Get implements the [flag.Getter] interface.
+// It returns the value of the field, e.g. required.Custom.
+func (v *Value) Get() any {
+	return v.v.Interface()
+}
+
+// IsBoolFlag reports whether the field is boolean, so that the flag is set without value.
+func (v *Value) IsBoolFlag() bool {
+	t := schematype.Target(v.v.Type())
+
+	return t != nil && t.Kind() == reflect.Bool
+}
+
+// fieldByPath returns the field of the struct pointed by root at the path of field names.
+// Nil pointers of nested structs are allocated.
+func fieldByPath(root reflect.Value, path string) (reflect.Value, bool) {
+	v := root
+
+	for name := range strings.SplitSeq(strings.TrimPrefix(path, "."), ".") {
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+
+			v = v.Elem()
+		}
+
+		// e.g. struct wrapped in a schema type
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+
+		v = v.FieldByName(name)
+	}
+
+	return v, v.IsValid() && v.CanSet()
+}
+
+func name(field reflect.StructField) string {
+	if tag, _, _ := strings.Cut(field.Tag.Get("flag"), ","); tag != "" {
+		return tag
+	}
+
+	return naming.Kebab(field.Name)
+}
@@ -0,0 +1,105 @@
+package schemaflag
+
+import (
+	"errors"
+	"flag"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+	"github.com/metafates/schema/validate"
+)
+
+type Database struct {
+	Host     required.NonZero[string]
+	MaxConns optional.Positive[int]
+}
+
+type Config struct {
+	Port     required.Positive[int] `usage:"port to listen on"`
+	Debug    bool
+	Verbose  optional.Any[bool]
+	Timeout  time.Duration
+	Tags     []string
+	Database *Database `flag:"db"`
+	Ignored  string    `flag:"-"`
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+
+	return fs
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		cfg := Config{Tags: []string{"default"}}
+
+		testutil.NoError(t, Parse(newFlagSet(), &cfg, []string{
+			"-port", "8080",
+			"-debug",
+			"-verbose",
+			"-timeout", "1m",
+			"-tags", "a", "-tags", "b",
+			"-db.host", "localhost",
+		}))
+
+		testutil.Equal(t, 8080, cfg.Port.Get())
+		testutil.Equal(t, true, cfg.Debug)
+		testutil.Equal(t, true, cfg.Verbose.Must())
+		testutil.Equal(t, time.Minute, cfg.Timeout)
+		testutil.DeepEqual(t, []string{"a", "b"}, cfg.Tags)
+		testutil.Equal(t, "localhost", cfg.Database.Host.Get())
+		testutil.Equal(t, false, cfg.Database.MaxConns.HasValue())
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		var errs validate.Errors
+
+		err := Parse(newFlagSet(), new(Config), []string{"-port", "-1", "-db.max-conns", "-2"})
+
+		testutil.Equal(t, true, errors.As(err, &errs))
+
+		names := make(map[string]bool)
+
+		for _, err := range errs {
+			names[err.Path()] = true
+		}
+
+		testutil.DeepEqual(t, map[string]bool{"port": true, "db.host": true, "db.max-conns": true}, names)
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+		testutil.Error(t, Parse(newFlagSet(), new(Config), []string{"-port", "many"}))
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		testutil.Error(t, Parse(newFlagSet(), new(Config), []string{"-ignored", "x"}))
+	})
+}
+
+func TestRegister(t *testing.T) {
+	cfg := Config{Timeout: time.Second}
+
+	fs := newFlagSet()
+
+	Register(fs, &cfg)
+
+	port := fs.Lookup("port")
+	testutil.Equal(t, "port to listen on", port.Usage)
+
+	testutil.Equal(t, "1s", fs.Lookup("timeout").DefValue)
+
+	testutil.NoError(t, fs.Set("port", "80"))
+
+	getter, ok := port.Value.(flag.Getter)
+	testutil.Equal(t, true, ok)
+	testutil.Equal(t, "80", getter.String())
+
+	_, ok = getter.Get().(required.Positive[int])
+	testutil.Equal(t, true, ok)
+}
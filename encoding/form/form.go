@@ -0,0 +1,365 @@
+// Package schemaform parses url.Values, e.g. query strings and HTML forms, into schema structs.
+package schemaform
+
+import (
+	"cmp"
+	"errors"
+	"maps"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/metafates/schema/internal/schematype"
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/validate"
+)
+
+// Error is an error of the form value with the given key.
+type Error struct {
+	// Key is the form key of the value, e.g. "addr.city" or "items[0].name".
+	// Fields without `form` tag are named after Go fields.
+	Key string
+
+	Err error
+}
+
+func (e Error) Error() string {
+	return "form: " + e.Key + ": " + e.Err.Error()
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Path returns the form key of the value which raised this error.
+// It makes [Error] a [validate.PathError].
+func (e Error) Path() string {
+	return e.Key
+}
+
+// Parse parses form values into dst and validates it.
+//
+// Keys are matched with dst fields by `form` tag or by Go name regardless of case.
+//   - Nested fields are addressed with dots or brackets, e.g. "addr.city" or "addr[city]".
+//   - List elements are addressed with indexes, e.g. "items[0].name".
+//   - Repeated keys, e.g. "tags=a&tags=b" or "tags[]=a&tags[]=b", are collected into slices.
+//   - Strings are coerced to the field types, see [parse.WithCoercion].
+//   - Empty values of non-string fields are treated as missing, as HTML forms submit empty inputs.
+//
+// Errors are reported with the form keys, see [Error].
+// Options are passed to [parse.Parse]. Field matching options are ignored.
+func Parse(values url.Values, dst any, options ...parse.Option) error {
+	dstType := reflect.TypeOf(dst)
+
+	value := newTree(values).value(dstType)
+
+	options = append([]parse.Option{parse.WithCoercion()}, options...)
+
+	// fields are already named after dst ones
+	options = append(options,
+		parse.WithFieldTag(""),
+		parse.WithFieldMatching(parse.MatchExact),
+		parse.WithRenameFunc(func(s string) string { return s }),
+	)
+
+	return keyErrors(parse.Parse(value, dst, options...), dstType)
+}
+
+// ParseRequest parses the form of r (query parameters and url-encoded body) into dst.
+// See [Parse].
+func ParseRequest(r *http.Request, dst any, options ...parse.Option) error {
+	if err := r.ParseForm(); err != nil {
+		return err
+	}
+
+	return Parse(r.Form, dst, options...)
+}
+
+// node is a form key segment with the values of this key and nested keys.
+type node struct {
+	values   []string
+	children map[string]*node
+}
+
+func newTree(values url.Values) *node {
+	root := new(node)
+
+	// keys are sorted for the stable order of values of the same key (e.g. "tags" and "tags[]")
+	for _, key := range slices.Sorted(maps.Keys(values)) {
+		n := root
+
+		for _, segment := range split(key) {
+			if n.children == nil {
+				n.children = make(map[string]*node)
+			}
+
+			child, ok := n.children[segment]
+			if !ok {
+				child = new(node)
+				n.children[segment] = child
+			}
+
+			n = child
+		}
+
+		n.values = append(n.values, values[key]...)
+	}
+
+	return root
+}
+
+// value converts n into a value which can be parsed into dst type.
+// Structs and maps are converted to map[string]any keyed by dst field names, lists to []any.
+// It returns nil if the value is missing.
+func (n *node) value(dst reflect.Type) any {
+	dst = schematype.Target(dst)
+
+	if len(n.children) == 0 {
+		return leaf(n.values, dst)
+	}
+
+	switch {
+	case dst != nil && dst.Kind() == reflect.Struct:
+		result := make(map[string]any, len(n.children))
+
+		for key, child := range n.children {
+			name, fieldType := field(dst, key)
+
+			if v := child.value(fieldType); v != nil {
+				result[name] = v
+			}
+		}
+
+		return result
+
+	case dst != nil && (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array):
+		keys := slices.SortedFunc(maps.Keys(n.children), compareIndexes)
+
+		result := make([]any, 0, len(keys))
+
+		for _, key := range keys {
+			result = append(result, n.children[key].value(dst.Elem()))
+		}
+
+		return result
+
+	default:
+		var elem reflect.Type
+
+		if dst != nil && dst.Kind() == reflect.Map {
+			elem = dst.Elem()
+		}
+
+		result := make(map[string]any, len(n.children))
+
+		for key, child := range n.children {
+			if v := child.value(elem); v != nil {
+				result[key] = v
+			}
+		}
+
+		return result
+	}
+}
+
+func leaf(values []string, dst reflect.Type) any {
+	if dst != nil && (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array) && dst.Elem().Kind() != reflect.Uint8 {
+		result := make([]any, 0, len(values))
+
+		for _, v := range values {
+			result = append(result, leaf([]string{v}, dst.Elem()))
+		}
+
+		return result
+	}
+
+	if len(values) == 0 {
+		return nil
+	}
+
+	value := values[0]
+
+	if value == "" && dst != nil && dst.Kind() != reflect.String && dst.Kind() != reflect.Interface {
+		return nil
+	}
+
+	return value
+}
+
+// field finds dst struct field matching the key and returns its name and type.
+// Unknown keys are returned as is.
+func field(dst reflect.Type, key string) (string, reflect.Type) {
+	for _, f := range reflect.VisibleFields(dst) {
+		if !f.IsExported() {
+			continue
+		}
+
+		tag, _, _ := strings.Cut(f.Tag.Get("form"), ",")
+
+		switch tag {
+		case "-":
+			continue
+
+		case "":
+			if strings.EqualFold(f.Name, key) {
+				return f.Name, f.Type
+			}
+
+		default:
+			if tag == key {
+				return f.Name, f.Type
+			}
+		}
+	}
+
+	return key, nil
+}
+
+// split splits the form key into segments, e.g. "items[0][name]", "items.0.name" and "items[0].name"
+// are all split into "items", "0" and "name". Empty brackets ("tags[]") are ignored.
+func split(key string) []string {
+	var segments []string
+
+	for key != "" {
+		end := strings.IndexAny(key, ".[")
+		if end < 0 {
+			return append(segments, key)
+		}
+
+		if end > 0 {
+			segments = append(segments, key[:end])
+		}
+
+		if key[end] == '.' {
+			key = key[end+1:]
+
+			continue
+		}
+
+		closing := strings.IndexByte(key[end:], ']')
+		if closing < 0 {
+			return append(segments, key[end:])
+		}
+
+		if segment := key[end+1 : end+closing]; segment != "" {
+			segments = append(segments, segment)
+		}
+
+		key = key[end+closing+1:]
+	}
+
+	return segments
+}
+
+// compareIndexes orders list indexes numerically. Non-numeric indexes go last.
+func compareIndexes(a, b string) int {
+	i, errA := strconv.Atoi(a)
+	j, errB := strconv.Atoi(b)
+
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(i, j)
+
+	case errA == nil:
+		return -1
+
+	case errB == nil:
+		return 1
+
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+// keyErrors replaces paths of Go fields in err with the form keys.
+func keyErrors(err error, dst reflect.Type) error {
+	var errs validate.Errors
+
+	if errors.As(err, &errs) {
+		result := make(validate.Errors, 0, len(errs))
+
+		for _, err := range errs {
+			result = append(result, Error{Key: key(dst, err.Path()), Err: err})
+		}
+
+		return result
+	}
+
+	var pathErr validate.PathError
+
+	if errors.As(err, &pathErr) && pathErr.Path() != "" {
+		return Error{Key: key(dst, pathErr.Path()), Err: err}
+	}
+
+	return err
+}
+
+// key returns the form key of the value at the path of dst,
+// e.g. ".Addr.City" is converted to "addr.city" for the fields tagged with `form:"addr"` and `form:"city"`.
+func key(dst reflect.Type, path string) string {
+	var b strings.Builder
+
+	for path != "" {
+		dst = schematype.Target(dst)
+
+		switch path[0] {
+		case '.':
+			name := path[1:]
+
+			end := strings.IndexAny(name, ".[")
+			if end < 0 {
+				end = len(name)
+			}
+
+			name, path = name[:end], name[end:]
+
+			var fieldType reflect.Type
+
+			if dst != nil && dst.Kind() == reflect.Struct {
+				if f, ok := dst.FieldByName(name); ok {
+					if tag, _, _ := strings.Cut(f.Tag.Get("form"), ","); tag != "" && tag != "-" {
+						name = tag
+					}
+
+					fieldType = f.Type
+				}
+			}
+
+			if b.Len() > 0 {
+				b.WriteByte('.')
+			}
+
+			b.WriteString(name)
+
+			dst = fieldType
+
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				b.WriteString(path)
+
+				return b.String()
+			}
+
+			b.WriteString(path[:end+1])
+
+			path = path[end+1:]
+
+			if dst != nil && (dst.Kind() == reflect.Slice || dst.Kind() == reflect.Array || dst.Kind() == reflect.Map) {
+				dst = dst.Elem()
+			} else {
+				dst = nil
+			}
+
+		default:
+			b.WriteString(path)
+
+			return b.String()
+		}
+	}
+
+	return b.String()
+}
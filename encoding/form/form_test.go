@@ -0,0 +1,130 @@
+package schemaform
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/required"
+	"github.com/metafates/schema/validate"
+)
+
+type Address struct {
+	City required.NonZero[string] `form:"city"`
+	Zip  optional.Any[string]     `form:"zip"`
+}
+
+type Item struct {
+	Name  required.NonZero[string] `form:"name"`
+	Count required.Positive[int]   `form:"count"`
+}
+
+type Search struct {
+	Query   required.NonZero[string] `form:"q"`
+	Page    optional.Positive[int]   `form:"page"`
+	Tags    []string                 `form:"tags"`
+	IDs     []int                    `form:"id"`
+	Addr    Address                  `form:"addr"`
+	Items   []Item                   `form:"items"`
+	Verbose bool
+}
+
+func TestParse(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		values, err := url.ParseQuery(
+			"q=shoes&tags=red&tags[]=blue&id=1&id=2&addr.city=Paris&addr[zip]=75001" +
+				"&items[1][name]=b&items[1].count=2&items[0].name=a&items[0].count=1&verbose=true",
+		)
+		testutil.NoError(t, err)
+
+		var search Search
+
+		testutil.NoError(t, Parse(values, &search))
+
+		testutil.Equal(t, "shoes", search.Query.Get())
+		testutil.Equal(t, false, search.Page.HasValue())
+		testutil.DeepEqual(t, []string{"red", "blue"}, search.Tags)
+		testutil.DeepEqual(t, []int{1, 2}, search.IDs)
+		testutil.Equal(t, "Paris", search.Addr.City.Get())
+		testutil.Equal(t, "75001", search.Addr.Zip.Must())
+		testutil.Equal(t, 2, len(search.Items))
+		testutil.Equal(t, "a", search.Items[0].Name.Get())
+		testutil.Equal(t, 2, search.Items[1].Count.Get())
+		testutil.Equal(t, true, search.Verbose)
+	})
+
+	t.Run("empty optional", func(t *testing.T) {
+		var search Search
+
+		testutil.NoError(t, Parse(url.Values{"q": {"shoes"}, "page": {""}, "addr.city": {"Paris"}}, &search))
+		testutil.Equal(t, false, search.Page.HasValue())
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		for _, tc := range []struct {
+			name   string
+			values url.Values
+			key    string
+		}{
+			{
+				name:   "missing",
+				values: url.Values{"q": {"shoes"}},
+				key:    "addr.city",
+			},
+			{
+				name:   "invalid",
+				values: url.Values{"q": {"shoes"}, "addr.city": {"Paris"}, "page": {"-1"}},
+				key:    "page",
+			},
+			{
+				name:   "unconvertible",
+				values: url.Values{"q": {"shoes"}, "addr.city": {"Paris"}, "items[0].name": {"a"}, "items[0].count": {"many"}},
+				key:    "items[0].count",
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				var formErr Error
+
+				err := Parse(tc.values, new(Search))
+
+				testutil.Equal(t, true, errors.As(err, &formErr))
+				testutil.Equal(t, tc.key, formErr.Key)
+			})
+		}
+	})
+}
+
+func TestParse_allErrors(t *testing.T) {
+	var errs validate.Errors
+
+	err := Parse(url.Values{"page": {"-1"}, "id": {"x"}}, new(Search), parse.WithAllErrors())
+
+	testutil.Equal(t, true, errors.As(err, &errs))
+
+	keys := make([]string, 0, len(errs))
+
+	for _, err := range errs {
+		keys = append(keys, err.Path())
+	}
+
+	slices.Sort(keys)
+
+	testutil.DeepEqual(t, []string{"addr.city", "id[0]", "page", "q"}, keys)
+}
+
+func TestParseRequest(t *testing.T) {
+	r := httptest.NewRequest("POST", "/search?q=shoes", strings.NewReader("addr.city=Paris&page=2"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var search Search
+
+	testutil.NoError(t, ParseRequest(r, &search))
+	testutil.Equal(t, "shoes", search.Query.Get())
+	testutil.Equal(t, 2, search.Page.Must())
+}
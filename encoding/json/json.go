@@ -3,7 +3,9 @@ package schemajson
 
 import (
 	"encoding/json"
+	"errors"
 	"io"
+	"iter"
 
 	"github.com/metafates/schema/validate"
 )
@@ -37,6 +39,38 @@ func (dec *Decoder) Decode(v any) error {
 	return nil
 }
 
+// Decode is a generic shorthand for [Decoder.Decode], which decodes the next value of type T from dec.
+func Decode[T any](dec *Decoder) (T, error) {
+	var v T
+
+	if err := dec.Decode(&v); err != nil {
+		return *new(T), err
+	}
+
+	return v, nil
+}
+
+// All returns an iterator over the values of type T in the dec stream.
+// Iteration stops at the end of the stream or after the first error is yielded.
+//
+//	for user, err := range schemajson.All[User](dec) {
+//		...
+//	}
+func All[T any](dec *Decoder) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for {
+			v, err := Decode[T](dec)
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if !yield(v, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
 // Unmarshal wraps [json.Unmarshal] and calls [validate.Validate] afterwards.
 //
 // See also [Decoder.Decode].
@@ -51,3 +85,14 @@ func Unmarshal(data []byte, v any) error {
 
 	return nil
 }
+
+// UnmarshalAs is a generic shorthand for [Unmarshal], which unmarshals data into a new value of type T.
+func UnmarshalAs[T any](data []byte) (T, error) {
+	var v T
+
+	if err := Unmarshal(data, &v); err != nil {
+		return *new(T), err
+	}
+
+	return v, nil
+}
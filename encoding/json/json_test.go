@@ -60,3 +60,47 @@ func TestJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestUnmarshalAs(t *testing.T) {
+	type Mock struct {
+		Bar optional.Positive[int] `json:"bar"`
+	}
+
+	mock, err := UnmarshalAs[Mock]([]byte(`{"bar": 249}`))
+	testutil.NoError(t, err)
+	testutil.Equal(t, 249, mock.Bar.Must())
+
+	_, err = UnmarshalAs[Mock]([]byte(`{"bar": -2}`))
+	testutil.Error(t, err)
+}
+
+func TestAll(t *testing.T) {
+	type Mock struct {
+		Bar optional.Positive[int] `json:"bar"`
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var bars []int
+
+		for mock, err := range All[Mock](NewDecoder(strings.NewReader(`{"bar": 1} {"bar": 2} {}`))) {
+			testutil.NoError(t, err)
+
+			bar, _ := mock.Bar.Get()
+			bars = append(bars, bar)
+		}
+
+		testutil.DeepEqual(t, []int{1, 2, 0}, bars)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var errs int
+
+		for _, err := range All[Mock](NewDecoder(strings.NewReader(`{"bar": 1} {"bar": -2} {"bar": 3}`))) {
+			if err != nil {
+				errs++
+			}
+		}
+
+		testutil.Equal(t, 1, errs)
+	})
+}
@@ -0,0 +1,75 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+// Package schemajson wraps [encoding/json/v2] decoding functions and calls validation after unmarshalling.
+//
+// It is only available with GOEXPERIMENT=jsonv2.
+package schemajson
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"io"
+
+	"github.com/metafates/schema/validate"
+)
+
+// Strict returns options which reject duplicate object names and unknown members.
+func Strict() json.Options {
+	return json.JoinOptions(
+		jsontext.AllowDuplicateNames(false),
+		json.RejectUnknownMembers(true),
+	)
+}
+
+// Unmarshal wraps [json.Unmarshal] and calls [validate.Validate] afterwards.
+//
+// See also [UnmarshalRead] and [UnmarshalDecode].
+func Unmarshal(in []byte, out any, opts ...json.Options) error {
+	if err := json.Unmarshal(in, out, opts...); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(out); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalRead wraps [json.UnmarshalRead] and calls [validate.Validate] afterwards.
+func UnmarshalRead(in io.Reader, out any, opts ...json.Options) error {
+	if err := json.UnmarshalRead(in, out, opts...); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(out); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalDecode wraps [json.UnmarshalDecode] and calls [validate.Validate] afterwards.
+// It decodes the next value from the stream, e.g. an element of the JSON lines input.
+func UnmarshalDecode(in *jsontext.Decoder, out any, opts ...json.Options) error {
+	if err := json.UnmarshalDecode(in, out, opts...); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(out); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UnmarshalAs is a generic shorthand for [Unmarshal], which unmarshals data into a new value of type T.
+func UnmarshalAs[T any](in []byte, opts ...json.Options) (T, error) {
+	var v T
+
+	if err := Unmarshal(in, &v, opts...); err != nil {
+		return *new(T), err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,137 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package schemajson
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+	"strings"
+	"testing"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+)
+
+type Mock struct {
+	Name required.NonZero[string] `json:"name"`
+	Age  optional.Positive[int8]  `json:"age,omitzero"`
+}
+
+func TestJSON(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		json    string
+		opts    []json.Options
+		wantErr bool
+	}{
+		{
+			name: "valid json",
+			json: `{"name": "john", "age": 42}`,
+		},
+		{
+			name: "null optional",
+			json: `{"name": "john", "age": null}`,
+		},
+		{
+			name:    "invalid json",
+			json:    `{"name": john}`,
+			wantErr: true,
+		},
+		{
+			name:    "validation error",
+			json:    `{"name": "john", "age": -2}`,
+			wantErr: true,
+		},
+		{
+			name:    "null required",
+			json:    `{"name": null}`,
+			wantErr: true,
+		},
+		{
+			name:    "missing required",
+			json:    `{"age": 42}`,
+			wantErr: true,
+		},
+		{
+			name:    "overflow",
+			json:    `{"name": "john", "age": 300}`,
+			wantErr: true,
+		},
+		{
+			name: "unknown member",
+			json: `{"name": "john", "foo": 1}`,
+		},
+		{
+			name:    "strict unknown member",
+			json:    `{"name": "john", "foo": 1}`,
+			opts:    []json.Options{Strict()},
+			wantErr: true,
+		},
+		{
+			name:    "strict duplicate name",
+			json:    `{"name": "john", "name": "jane"}`,
+			opts:    []json.Options{Strict()},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("unmarshal", func(t *testing.T) {
+				var mock Mock
+				err := Unmarshal([]byte(tc.json), &mock, tc.opts...)
+
+				if tc.wantErr {
+					testutil.Error(t, err)
+				} else {
+					testutil.NoError(t, err)
+				}
+			})
+
+			t.Run("read", func(t *testing.T) {
+				var mock Mock
+				err := UnmarshalRead(strings.NewReader(tc.json), &mock, tc.opts...)
+
+				if tc.wantErr {
+					testutil.Error(t, err)
+				} else {
+					testutil.NoError(t, err)
+				}
+			})
+		})
+	}
+}
+
+func TestUnmarshalDecode(t *testing.T) {
+	dec := jsontext.NewDecoder(strings.NewReader(`{"name": "john"} {"name": ""}`))
+
+	var mock Mock
+
+	testutil.NoError(t, UnmarshalDecode(dec, &mock))
+	testutil.Equal(t, "john", mock.Name.Get())
+	testutil.Error(t, UnmarshalDecode(dec, &mock))
+}
+
+func TestJSON_roundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		json string
+	}{
+		{name: "present", json: `{"name":"john","age":42}`},
+		{name: "missing omitted", json: `{"name":"john"}`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mock, err := UnmarshalAs[Mock]([]byte(tc.json))
+			testutil.NoError(t, err)
+
+			data, err := json.Marshal(mock)
+			testutil.NoError(t, err)
+			testutil.Equal(t, tc.json, string(data))
+		})
+	}
+}
+
+func TestJSON_unvalidated(t *testing.T) {
+	testutil.Panic(t, func() {
+		_, _ = json.Marshal(Mock{})
+	})
+}
@@ -0,0 +1,57 @@
+// Package schemamsgpack wraps MessagePack decoding functions and calls validation after unmarshalling.
+//
+// Fields of required and optional types are decoded into their underlying types directly,
+// so that integer and float widths are preserved. Nil values are missing.
+package schemamsgpack
+
+import (
+	"io"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/metafates/schema/validate"
+)
+
+// Decoder wraps [msgpack.Decoder] with validation step after decoding.
+//
+// See [msgpack.Decoder] documentation.
+type Decoder struct {
+	*msgpack.Decoder
+}
+
+// NewDecoder returns a new decoder that reads from r.
+//
+// See [msgpack.NewDecoder] documentation.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{msgpack.NewDecoder(r)}
+}
+
+// Decode wraps [msgpack.Decoder.Decode] and calls [validate.Validate] afterwards.
+//
+// See also [Unmarshal].
+func (dec *Decoder) Decode(v any) error {
+	if err := dec.Decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Unmarshal wraps [msgpack.Unmarshal] and calls [validate.Validate] afterwards.
+//
+// See also [Decoder.Decode].
+func Unmarshal(data []byte, v any) error {
+	if err := msgpack.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(v); err != nil {
+		return err
+	}
+
+	return nil
+}
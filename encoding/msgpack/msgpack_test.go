@@ -0,0 +1,34 @@
+package schemamsgpack
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/vmihailenco/msgpack/v5"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+)
+
+func TestMsgpack(t *testing.T) {
+	type Mock struct {
+		Name required.NonZero[string] `msgpack:"name"`
+		Age  optional.Positive[int8]  `msgpack:"age"`
+	}
+
+	valid, err := msgpack.Marshal(map[string]any{"name": "john", "age": 42})
+	testutil.NoError(t, err)
+
+	invalid, err := msgpack.Marshal(map[string]any{"name": "john", "age": -2})
+	testutil.NoError(t, err)
+
+	var mock Mock
+
+	testutil.NoError(t, Unmarshal(valid, &mock))
+	testutil.Equal(t, int8(42), mock.Age.Must())
+	testutil.Error(t, Unmarshal(invalid, &mock))
+
+	testutil.NoError(t, NewDecoder(bytes.NewReader(valid)).Decode(&mock))
+	testutil.Error(t, NewDecoder(bytes.NewReader(invalid)).Decode(&mock))
+}
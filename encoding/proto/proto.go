@@ -0,0 +1,433 @@
+// Package schemaproto parses protobuf messages into schema structs and exports them back using protobuf reflection.
+package schemaproto
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/metafates/schema/internal/schematype"
+	"github.com/metafates/schema/parse"
+)
+
+// Parse parses protobuf message src into dst and validates it.
+//
+// Unlike [parse.Parse] with message as src, it uses protobuf reflection, so that:
+//   - Fields are matched by proto name or json_name regardless of case and underscores, e.g. "user_id" and "userId" match UserID field.
+//   - Well-known google.protobuf.Timestamp, Duration and wrappers are converted to [time.Time], [time.Duration] and their values.
+//   - Enums are converted to their names for string fields and to their numbers otherwise.
+//   - Fields with presence (proto3 optional, oneof members, messages and wrappers) are left missing if not set,
+//     so that optional types are filled correctly.
+//
+// Options are passed to [parse.Parse]. Field matching options are ignored.
+func Parse(src proto.Message, dst any, options ...parse.Option) error {
+	var value any
+
+	if src != nil {
+		value = message(src.ProtoReflect(), reflect.TypeOf(dst))
+	}
+
+	// fields are already named after dst ones,
+	// full slice expression makes append copy options instead of writing to the caller's array
+	options = append(options[:len(options):len(options)],
+		parse.WithFieldTag(""),
+		parse.WithFieldMatching(parse.MatchExact),
+		parse.WithRenameFunc(func(s string) string { return s }),
+	)
+
+	return parse.Parse(value, dst, options...)
+}
+
+// elem returns the type of the elements of list or map value parsed into t.
+func elem(t reflect.Type) reflect.Type {
+	t = schematype.Target(t)
+
+	if t == nil {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return t.Elem()
+
+	default:
+		return nil
+	}
+}
+
+// message converts m into a map keyed by names of dst fields.
+// Fields without a match in dst are keyed by their proto names.
+func message(m protoreflect.Message, dst reflect.Type) map[string]any {
+	dst = schematype.Target(dst)
+
+	fields := m.Descriptor().Fields()
+
+	result := make(map[string]any, fields.Len())
+
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+
+		// unset fields are missing, except for the scalars without presence (their zero value is set)
+		if (fd.HasPresence() || fd.IsList() || fd.IsMap()) && !m.Has(fd) {
+			continue
+		}
+
+		name, fieldType := field(fd, dst)
+
+		result[name] = value(fd, m.Get(fd), fieldType)
+	}
+
+	return result
+}
+
+// field finds dst struct field matching fd and returns its name and type.
+func field(fd protoreflect.FieldDescriptor, dst reflect.Type) (string, reflect.Type) {
+	if dst == nil || dst.Kind() != reflect.Struct {
+		return string(fd.Name()), elem(dst)
+	}
+
+	for _, f := range reflect.VisibleFields(dst) {
+		if !f.IsExported() {
+			continue
+		}
+
+		names := []string{f.Name}
+
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag != "" && tag != "-" {
+			names = append(names, tag)
+		}
+
+		for _, name := range names {
+			if fold(name) == fold(string(fd.Name())) || fold(name) == fold(fd.JSONName()) {
+				return f.Name, f.Type
+			}
+		}
+	}
+
+	return string(fd.Name()), nil
+}
+
+func fold(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
+
+func value(fd protoreflect.FieldDescriptor, v protoreflect.Value, dst reflect.Type) any {
+	switch {
+	case fd.IsList():
+		list := v.List()
+		elemType := elem(dst)
+
+		result := make([]any, list.Len())
+
+		for i := range list.Len() {
+			result[i] = singular(fd, list.Get(i), elemType)
+		}
+
+		return result
+
+	case fd.IsMap():
+		result := make(map[any]any, v.Map().Len())
+		elemType := elem(dst)
+
+		v.Map().Range(func(key protoreflect.MapKey, v protoreflect.Value) bool {
+			result[key.Interface()] = singular(fd.MapValue(), v, elemType)
+
+			return true
+		})
+
+		return result
+
+	default:
+		return singular(fd, v, dst)
+	}
+}
+
+func singular(fd protoreflect.FieldDescriptor, v protoreflect.Value, dst reflect.Type) any {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		return enum(fd.Enum(), v.Enum(), dst)
+
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return wellKnown(v.Message(), dst)
+
+	case protoreflect.BytesKind:
+		return append([]byte(nil), v.Bytes()...)
+
+	default:
+		return v.Interface()
+	}
+}
+
+func enum(ed protoreflect.EnumDescriptor, number protoreflect.EnumNumber, dst reflect.Type) any {
+	if t := schematype.Target(dst); t != nil && t.Kind() == reflect.String {
+		if value := ed.Values().ByNumber(number); value != nil {
+			return string(value.Name())
+		}
+	}
+
+	return int32(number)
+}
+
+func wellKnown(m protoreflect.Message, dst reflect.Type) any {
+	fields := m.Descriptor().Fields()
+
+	switch m.Descriptor().FullName() {
+	case "google.protobuf.Timestamp":
+		seconds := m.Get(fields.ByName("seconds")).Int()
+		nanos := m.Get(fields.ByName("nanos")).Int()
+
+		return time.Unix(seconds, nanos).UTC()
+
+	case "google.protobuf.Duration":
+		seconds := m.Get(fields.ByName("seconds")).Int()
+		nanos := m.Get(fields.ByName("nanos")).Int()
+
+		return time.Duration(seconds)*time.Second + time.Duration(nanos)
+
+	case "google.protobuf.DoubleValue",
+		"google.protobuf.FloatValue",
+		"google.protobuf.Int64Value",
+		"google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value",
+		"google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue",
+		"google.protobuf.StringValue",
+		"google.protobuf.BytesValue":
+		return singular(fields.ByName("value"), m.Get(fields.ByName("value")), dst)
+
+	default:
+		return message(m, dst)
+	}
+}
+
+// Export is the reverse of [Parse]. It copies schema value src into protobuf message dst.
+// See [parse.Export].
+//
+// Fields are matched with src fields by proto name or json_name regardless of case and underscores.
+// [time.Time], [time.Duration] and plain values are converted to well-known google.protobuf.Timestamp, Duration
+// and wrappers, enums are set by their names or numbers.
+// Missing optional values are left unset.
+//
+// Options are passed to [parse.Export].
+func Export(src any, dst proto.Message, options ...parse.Option) error {
+	var fields map[string]any
+
+	if err := parse.Export(src, &fields, options...); err != nil {
+		return err
+	}
+
+	return fill(dst.ProtoReflect(), fields, options)
+}
+
+// fill sets fields of m from values keyed by the field names.
+// Values without a matching field are ignored.
+func fill(m protoreflect.Message, values map[string]any, options []parse.Option) error {
+	fields := m.Descriptor().Fields()
+
+	for key, v := range values {
+		if v == nil {
+			continue
+		}
+
+		var fd protoreflect.FieldDescriptor
+
+		for i := range fields.Len() {
+			if fold(key) == fold(string(fields.Get(i).Name())) || fold(key) == fold(fields.Get(i).JSONName()) {
+				fd = fields.Get(i)
+
+				break
+			}
+		}
+
+		if fd == nil {
+			continue
+		}
+
+		if err := set(m, fd, v, options); err != nil {
+			return fmt.Errorf("%s: %w", fd.FullName(), err)
+		}
+	}
+
+	return nil
+}
+
+func set(m protoreflect.Message, fd protoreflect.FieldDescriptor, v any, options []parse.Option) error {
+	switch {
+	case fd.IsList():
+		values, err := parse.Convert[[]any](v, options...)
+		if err != nil {
+			return err
+		}
+
+		list := m.Mutable(fd).List()
+
+		for _, v := range values {
+			value, err := protoValue(fd, v, list.NewElement, options)
+			if err != nil {
+				return err
+			}
+
+			list.Append(value)
+		}
+
+	case fd.IsMap():
+		values, err := parse.Convert[map[any]any](v, options...)
+		if err != nil {
+			return err
+		}
+
+		entries := m.Mutable(fd).Map()
+
+		for k, v := range values {
+			key, err := protoValue(fd.MapKey(), k, nil, options)
+			if err != nil {
+				return err
+			}
+
+			value, err := protoValue(fd.MapValue(), v, entries.NewValue, options)
+			if err != nil {
+				return err
+			}
+
+			entries.Set(key.MapKey(), value)
+		}
+
+	default:
+		value, err := protoValue(fd, v, func() protoreflect.Value { return m.NewField(fd) }, options)
+		if err != nil {
+			return err
+		}
+
+		m.Set(fd, value)
+	}
+
+	return nil
+}
+
+// protoValue converts v into value of singular field fd.
+// newMessage is used to create message values.
+func protoValue(
+	fd protoreflect.FieldDescriptor,
+	v any,
+	newMessage func() protoreflect.Value,
+	options []parse.Option,
+) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.EnumKind:
+		if name, ok := v.(string); ok {
+			value := fd.Enum().Values().ByName(protoreflect.Name(name))
+			if value == nil {
+				return protoreflect.Value{}, fmt.Errorf("unknown %s value %q", fd.Enum().FullName(), name)
+			}
+
+			return protoreflect.ValueOfEnum(value.Number()), nil
+		}
+
+		number, err := parse.Convert[int32](v, options...)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(number)), nil
+
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		value := newMessage()
+
+		if err := fillMessage(value.Message(), v, options); err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return value, nil
+
+	case protoreflect.BoolKind:
+		return convert[bool](v, options)
+
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return convert[int32](v, options)
+
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return convert[int64](v, options)
+
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return convert[uint32](v, options)
+
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return convert[uint64](v, options)
+
+	case protoreflect.FloatKind:
+		return convert[float32](v, options)
+
+	case protoreflect.DoubleKind:
+		return convert[float64](v, options)
+
+	case protoreflect.StringKind:
+		return convert[string](v, options)
+
+	case protoreflect.BytesKind:
+		return convert[[]byte](v, options)
+
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", fd.Kind())
+	}
+}
+
+func convert[T any](v any, options []parse.Option) (protoreflect.Value, error) {
+	value, err := parse.Convert[T](v, options...)
+	if err != nil {
+		return protoreflect.Value{}, err
+	}
+
+	return protoreflect.ValueOf(value), nil
+}
+
+// fillMessage sets m from v, which is either a well-known type value or fields map.
+func fillMessage(m protoreflect.Message, v any, options []parse.Option) error {
+	fields := m.Descriptor().Fields()
+
+	switch m.Descriptor().FullName() {
+	case "google.protobuf.Timestamp":
+		t, err := parse.Convert[time.Time](v, options...)
+		if err != nil {
+			return err
+		}
+
+		m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(t.Unix()))
+		m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(t.Nanosecond())))
+
+		return nil
+
+	case "google.protobuf.Duration":
+		d, err := parse.Convert[time.Duration](v, options...)
+		if err != nil {
+			return err
+		}
+
+		m.Set(fields.ByName("seconds"), protoreflect.ValueOfInt64(int64(d/time.Second)))
+		m.Set(fields.ByName("nanos"), protoreflect.ValueOfInt32(int32(d%time.Second)))
+
+		return nil
+
+	case "google.protobuf.DoubleValue",
+		"google.protobuf.FloatValue",
+		"google.protobuf.Int64Value",
+		"google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value",
+		"google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue",
+		"google.protobuf.StringValue",
+		"google.protobuf.BytesValue":
+		return set(m, fields.ByName("value"), v, options)
+
+	default:
+		values, err := parse.Convert[map[string]any](v, options...)
+		if err != nil {
+			return err
+		}
+
+		return fill(m, values, options)
+	}
+}
@@ -0,0 +1,279 @@
+package schemaproto
+
+import (
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/required"
+)
+
+// userDescriptor describes the following message:
+//
+//	enum Status { STATUS_UNSPECIFIED = 0; STATUS_ACTIVE = 1; }
+//
+//	message Item { string name = 1; }
+//
+//	message User {
+//	  string user_id = 1;
+//	  optional int32 age = 2;
+//	  Status status = 3;
+//	  Status status_code = 4;
+//	  google.protobuf.Timestamp created_at = 5;
+//	  google.protobuf.Duration timeout = 6;
+//	  google.protobuf.StringValue nickname = 7;
+//	  repeated Item items = 8;
+//	  map<string, int32> scores = 9;
+//	  oneof contact { string email = 10; string phone = 11; }
+//	}
+func userDescriptor(t *testing.T) protoreflect.MessageDescriptor {
+	t.Helper()
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:   proto.String(name),
+			Number: proto.Int32(number),
+			Type:   typ.Enum(),
+			Label:  descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+		}
+
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+
+		return f
+	}
+
+	age := field("age", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, "")
+	age.Proto3Optional = proto.Bool(true)
+	age.OneofIndex = proto.Int32(1)
+
+	items := field("items", 8, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.Item")
+	items.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+
+	scores := field("scores", 9, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".test.User.ScoresEntry")
+	scores.Label = descriptorpb.FieldDescriptorProto_LABEL_REPEATED.Enum()
+
+	email := field("email", 10, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+	email.OneofIndex = proto.Int32(0)
+
+	phone := field("phone", 11, descriptorpb.FieldDescriptorProto_TYPE_STRING, "")
+	phone.OneofIndex = proto.Int32(0)
+
+	file := &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		Dependency: []string{
+			"google/protobuf/timestamp.proto",
+			"google/protobuf/duration.proto",
+			"google/protobuf/wrappers.proto",
+		},
+		EnumType: []*descriptorpb.EnumDescriptorProto{
+			{
+				Name: proto.String("Status"),
+				Value: []*descriptorpb.EnumValueDescriptorProto{
+					{Name: proto.String("STATUS_UNSPECIFIED"), Number: proto.Int32(0)},
+					{Name: proto.String("STATUS_ACTIVE"), Number: proto.Int32(1)},
+				},
+			},
+		},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Item"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("name", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+				},
+			},
+			{
+				Name: proto.String("User"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("user_id", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+					age,
+					field("status", 3, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".test.Status"),
+					field("status_code", 4, descriptorpb.FieldDescriptorProto_TYPE_ENUM, ".test.Status"),
+					field("created_at", 5, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Timestamp"),
+					field("timeout", 6, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.Duration"),
+					field("nickname", 7, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, ".google.protobuf.StringValue"),
+					items,
+					scores,
+					email,
+					phone,
+				},
+				NestedType: []*descriptorpb.DescriptorProto{
+					{
+						Name: proto.String("ScoresEntry"),
+						Field: []*descriptorpb.FieldDescriptorProto{
+							field("key", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, ""),
+							field("value", 2, descriptorpb.FieldDescriptorProto_TYPE_INT32, ""),
+						},
+						Options: &descriptorpb.MessageOptions{MapEntry: proto.Bool(true)},
+					},
+				},
+				OneofDecl: []*descriptorpb.OneofDescriptorProto{
+					{Name: proto.String("contact")},
+					{Name: proto.String("_age")},
+				},
+			},
+		},
+	}
+
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	testutil.NoError(t, err)
+
+	return fd.Messages().ByName("User")
+}
+
+func TestParse(t *testing.T) {
+	type Item struct {
+		Name required.Any[string]
+	}
+
+	type User struct {
+		UserID     required.Any[string] `json:"userId"`
+		Age        optional.Any[int]
+		Status     string
+		StatusCode int
+		CreatedAt  required.Any[time.Time]
+		Timeout    time.Duration
+		Nickname   optional.Any[string]
+		Items      []Item
+		Scores     map[string]int
+		Email      optional.Any[string]
+		Phone      optional.Any[string]
+	}
+
+	desc := userDescriptor(t)
+	fields := desc.Fields()
+
+	created := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	newUser := func() *dynamicpb.Message {
+		msg := dynamicpb.NewMessage(desc)
+
+		msg.Set(fields.ByName("user_id"), protoreflect.ValueOfString("u1"))
+		msg.Set(fields.ByName("status"), protoreflect.ValueOfEnum(1))
+		msg.Set(fields.ByName("status_code"), protoreflect.ValueOfEnum(1))
+		msg.Set(fields.ByName("created_at"), protoreflect.ValueOfMessage(timestamppb.New(created).ProtoReflect()))
+
+		return msg
+	}
+
+	t.Run("full", func(t *testing.T) {
+		msg := newUser()
+
+		msg.Set(fields.ByName("age"), protoreflect.ValueOfInt32(0))
+		msg.Set(fields.ByName("timeout"), protoreflect.ValueOfMessage(durationpb.New(90*time.Second).ProtoReflect()))
+		msg.Set(fields.ByName("nickname"), protoreflect.ValueOfMessage(wrapperspb.String("johnny").ProtoReflect()))
+		msg.Set(fields.ByName("phone"), protoreflect.ValueOfString("123"))
+
+		items := msg.Mutable(fields.ByName("items")).List()
+		item := items.NewElement()
+		item.Message().Set(item.Message().Descriptor().Fields().ByName("name"), protoreflect.ValueOfString("apple"))
+		items.Append(item)
+
+		msg.Mutable(fields.ByName("scores")).Map().Set(
+			protoreflect.ValueOfString("math").MapKey(),
+			protoreflect.ValueOfInt32(5),
+		)
+
+		var user User
+
+		testutil.NoError(t, Parse(msg, &user))
+
+		testutil.Equal(t, "u1", user.UserID.Get())
+
+		age, ok := user.Age.Get()
+		testutil.Equal(t, true, ok)
+		testutil.Equal(t, 0, age)
+
+		testutil.Equal(t, "STATUS_ACTIVE", user.Status)
+		testutil.Equal(t, 1, user.StatusCode)
+		testutil.Equal(t, true, created.Equal(user.CreatedAt.Get()))
+		testutil.Equal(t, 90*time.Second, user.Timeout)
+		testutil.Equal(t, "johnny", user.Nickname.Must())
+		testutil.Equal(t, 1, len(user.Items))
+		testutil.Equal(t, "apple", user.Items[0].Name.Get())
+		testutil.DeepEqual(t, map[string]int{"math": 5}, user.Scores)
+		testutil.Equal(t, false, user.Email.HasValue())
+		testutil.Equal(t, "123", user.Phone.Must())
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var user User
+
+		testutil.NoError(t, Parse(newUser(), &user))
+
+		testutil.Equal(t, false, user.Age.HasValue())
+		testutil.Equal(t, false, user.Nickname.HasValue())
+		testutil.Equal(t, false, user.Email.HasValue())
+		testutil.Equal(t, false, user.Phone.HasValue())
+		testutil.Equal(t, 0, len(user.Items))
+	})
+
+	t.Run("options", func(t *testing.T) {
+		var user User
+
+		// spare capacity of the caller's options must not be written to
+		options := make([]parse.Option, 1, 4)
+		options[0] = parse.WithCoercion()
+
+		testutil.NoError(t, Parse(newUser(), &user, options...))
+
+		for _, option := range options[1:cap(options)] {
+			testutil.Equal(t, true, option == nil)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var user User
+
+		msg := newUser()
+		msg.Clear(fields.ByName("created_at"))
+
+		testutil.Error(t, Parse(msg, &user))
+	})
+
+	t.Run("export", func(t *testing.T) {
+		msg := newUser()
+
+		msg.Set(fields.ByName("age"), protoreflect.ValueOfInt32(0))
+		msg.Set(fields.ByName("timeout"), protoreflect.ValueOfMessage(durationpb.New(1500*time.Millisecond).ProtoReflect()))
+		msg.Set(fields.ByName("nickname"), protoreflect.ValueOfMessage(wrapperspb.String("johnny").ProtoReflect()))
+		msg.Set(fields.ByName("email"), protoreflect.ValueOfString("john@example.com"))
+		msg.Mutable(fields.ByName("scores")).Map().Set(
+			protoreflect.ValueOfString("math").MapKey(),
+			protoreflect.ValueOfInt32(5),
+		)
+
+		items := msg.Mutable(fields.ByName("items")).List()
+		item := items.NewElement()
+		item.Message().Set(item.Message().Descriptor().Fields().ByName("name"), protoreflect.ValueOfString("apple"))
+		items.Append(item)
+
+		var user User
+
+		testutil.NoError(t, Parse(msg, &user))
+
+		exported := dynamicpb.NewMessage(desc)
+
+		testutil.NoError(t, Export(user, exported))
+		testutil.Equal(t, true, proto.Equal(msg, exported))
+	})
+
+	t.Run("export invalid", func(t *testing.T) {
+		testutil.Error(t, Export(User{}, dynamicpb.NewMessage(desc)))
+	})
+}
@@ -0,0 +1,66 @@
+// Package schemaxml wraps xml decoding functions and calls validation after unmarshalling.
+package schemaxml
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/metafates/schema/validate"
+)
+
+// Decoder wraps [xml.Decoder] with validation step after decoding.
+//
+// See [xml.Decoder] documentation.
+type Decoder struct {
+	*xml.Decoder
+}
+
+// NewDecoder returns a new decoder that reads from r.
+//
+// See [xml.NewDecoder] documentation.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{xml.NewDecoder(r)}
+}
+
+// Decode wraps [xml.Decoder.Decode] and calls [validate.Validate] afterwards.
+//
+// See also [Unmarshal].
+func (dec *Decoder) Decode(v any) error {
+	if err := dec.Decoder.Decode(v); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// DecodeElement wraps [xml.Decoder.DecodeElement] and calls [validate.Validate] afterwards.
+func (dec *Decoder) DecodeElement(v any, start *xml.StartElement) error {
+	if err := dec.Decoder.DecodeElement(v, start); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(v); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Unmarshal wraps [xml.Unmarshal] and calls [validate.Validate] afterwards.
+//
+// See also [Decoder.Decode].
+func Unmarshal(data []byte, v any) error {
+	if err := xml.Unmarshal(data, v); err != nil {
+		return err
+	}
+
+	if err := validate.Validate(v); err != nil {
+		return err
+	}
+
+	return nil
+}
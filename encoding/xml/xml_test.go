@@ -0,0 +1,99 @@
+package schemaxml
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+)
+
+type Mock struct {
+	XMLName xml.Name                 `xml:"mock"`
+	ID      required.Positive[int]   `xml:"id,attr"`
+	Lang    optional.Any[string]     `xml:"lang,attr"`
+	Foo     string                   `xml:"foo"`
+	Bar     optional.Positive[int]   `xml:"bar"`
+	Name    required.NonZero[string] `xml:"name"`
+}
+
+func TestXML(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		xml     string
+		wantErr bool
+	}{
+		{
+			name: "valid xml",
+			xml:  `<mock id="1"><foo>lorem ipsum</foo><bar>249</bar><name>john</name></mock>`,
+		},
+		{
+			name:    "invalid xml",
+			xml:     `<mock id="1"><foo>lorem ipsum</bar></mock>`,
+			wantErr: true,
+		},
+		{
+			name:    "validation error",
+			xml:     `<mock id="1"><foo>lorem ipsum</foo><bar>-2</bar><name>john</name></mock>`,
+			wantErr: true,
+		},
+		{
+			name:    "missing attribute",
+			xml:     `<mock><name>john</name></mock>`,
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("unmarshal", func(t *testing.T) {
+				var mock Mock
+				err := Unmarshal([]byte(tc.xml), &mock)
+
+				if tc.wantErr {
+					testutil.Error(t, err)
+				} else {
+					testutil.NoError(t, err)
+				}
+			})
+
+			t.Run("decoder", func(t *testing.T) {
+				var mock Mock
+
+				err := NewDecoder(strings.NewReader(tc.xml)).Decode(&mock)
+
+				if tc.wantErr {
+					testutil.Error(t, err)
+				} else {
+					testutil.NoError(t, err)
+				}
+			})
+		})
+	}
+}
+
+func TestXML_roundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		xml  string
+	}{
+		{
+			name: "missing optional",
+			xml:  `<mock id="1"><foo></foo><name>john</name></mock>`,
+		},
+		{
+			name: "empty optional",
+			xml:  `<mock id="1" lang=""><foo></foo><bar>2</bar><name>john</name></mock>`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var mock Mock
+
+			testutil.NoError(t, Unmarshal([]byte(tc.xml), &mock))
+
+			data, err := xml.Marshal(mock)
+			testutil.NoError(t, err)
+			testutil.Equal(t, tc.xml, string(data))
+		})
+	}
+}
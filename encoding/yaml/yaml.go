@@ -0,0 +1,238 @@
+// Package schemayaml wraps yaml decoding functions and calls validation after unmarshalling.
+package schemayaml
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/metafates/schema/internal/schematype"
+	"github.com/metafates/schema/validate"
+)
+
+// Error is a validation error with the position of the invalid value in the yaml document.
+// Missing values are reported at the position of their parent mapping.
+type Error struct {
+	Line, Column int
+
+	Err error
+}
+
+func (e Error) Error() string {
+	return fmt.Sprintf("yaml: line %d, column %d: %s", e.Line, e.Column, e.Err)
+}
+
+func (e Error) Unwrap() error {
+	return e.Err
+}
+
+// Path returns the path to the value which raised this error, e.g. ".Friends[0].Name".
+func (e Error) Path() string {
+	var pathErr validate.PathError
+
+	if errors.As(e.Err, &pathErr) {
+		return pathErr.Path()
+	}
+
+	return ""
+}
+
+// Decoder wraps [yaml.Decoder] with validation step after decoding.
+//
+// See [yaml.Decoder] documentation.
+type Decoder struct {
+	*yaml.Decoder
+}
+
+// NewDecoder returns a new decoder that reads from r.
+//
+// See [yaml.NewDecoder] documentation.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{yaml.NewDecoder(r)}
+}
+
+// Decode wraps [yaml.Decoder.Decode] and calls [validate.Validate] afterwards.
+// Validation errors are wrapped in [Error] with the position of the invalid value.
+//
+// See also [Unmarshal].
+func (dec *Decoder) Decode(v any) error {
+	var node yaml.Node
+
+	if err := dec.Decoder.Decode(&node); err != nil {
+		return err
+	}
+
+	return decode(&node, v)
+}
+
+// Unmarshal wraps [yaml.Unmarshal] and calls [validate.Validate] afterwards.
+// Validation errors are wrapped in [Error] with the position of the invalid value.
+//
+// See also [Decoder.Decode].
+func Unmarshal(data []byte, v any) error {
+	var node yaml.Node
+
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return err
+	}
+
+	return decode(&node, v)
+}
+
+func decode(node *yaml.Node, v any) error {
+	// empty document
+	if node.Kind == 0 {
+		return validate.Validate(v)
+	}
+
+	if err := node.Decode(v); err != nil {
+		return err
+	}
+
+	err := validate.Validate(v)
+
+	var pathErr validate.PathError
+
+	if !errors.As(err, &pathErr) {
+		return err
+	}
+
+	at := find(node, reflect.TypeOf(v), pathErr.Path())
+
+	return Error{Line: at.Line, Column: at.Column, Err: err}
+}
+
+// find returns the node of the value at the path of t.
+// If the value is missing, the closest parent node is returned.
+func find(node *yaml.Node, t reflect.Type, path string) *yaml.Node {
+	for {
+		switch node.Kind {
+		case yaml.DocumentNode:
+			if len(node.Content) == 0 {
+				return node
+			}
+
+			node = node.Content[0]
+
+			continue
+
+		case yaml.AliasNode:
+			node = node.Alias
+
+			continue
+		}
+
+		t = schematype.Target(t)
+
+		if path == "" || t == nil {
+			return node
+		}
+
+		var (
+			child     *yaml.Node
+			childType reflect.Type
+		)
+
+		switch path[0] {
+		case '.':
+			name := path[1:]
+
+			end := strings.IndexAny(name, ".[")
+			if end < 0 {
+				end = len(name)
+			}
+
+			name, path = name[:end], name[end:]
+
+			if t.Kind() != reflect.Struct {
+				return node
+			}
+
+			field, ok := t.FieldByName(name)
+			if !ok {
+				return node
+			}
+
+			child, childType = value(node, key(field)), field.Type
+
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return node
+			}
+
+			index := path[1:end]
+			path = path[end+1:]
+
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array:
+				i, err := strconv.Atoi(index)
+				if err == nil && node.Kind == yaml.SequenceNode && i < len(node.Content) {
+					child = node.Content[i]
+				}
+
+			case reflect.Map:
+				child = value(node, index)
+			}
+
+			if t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+				childType = t.Elem()
+			}
+
+		default:
+			return node
+		}
+
+		if child == nil {
+			return node
+		}
+
+		node, t = child, childType
+	}
+}
+
+// value returns the value of the key in mapping node or nil if there is no such key.
+// Keys of the inlined mappings (merge keys) are also searched.
+func value(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+
+		if k.Value == key {
+			return v
+		}
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+
+		if k.Tag == "!!merge" {
+			if v.Kind == yaml.AliasNode {
+				v = v.Alias
+			}
+
+			if found := value(v, key); found != nil {
+				return found
+			}
+		}
+	}
+
+	return nil
+}
+
+// key returns the yaml key of the field, which is yaml tag or lowercased field name.
+func key(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("yaml"), ","); name != "" && name != "-" {
+		return name
+	}
+
+	return strings.ToLower(field.Name)
+}
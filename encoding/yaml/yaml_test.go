@@ -0,0 +1,138 @@
+package schemayaml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+)
+
+type Item struct {
+	Name  required.NonZero[string] `yaml:"name"`
+	Count optional.Positive[int]   `yaml:"count"`
+}
+
+type Mock struct {
+	Foo   string                 `yaml:"foo"`
+	Bar   optional.Positive[int] `yaml:"bar"`
+	Items []Item                 `yaml:"items"`
+}
+
+func TestYAML(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		yaml    string
+		wantErr bool
+	}{
+		{
+			name: "valid yaml",
+			yaml: "foo: lorem ipsum\nbar: 249\nitems:\n  - name: apple\n",
+		},
+		{
+			name:    "invalid yaml",
+			yaml:    "foo: [lorem ipsum\nbar: 249",
+			wantErr: true,
+		},
+		{
+			name:    "validation error",
+			yaml:    "foo: lorem ipsum\nbar: -2",
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Run("unmarshal", func(t *testing.T) {
+				var mock Mock
+				err := Unmarshal([]byte(tc.yaml), &mock)
+
+				if tc.wantErr {
+					testutil.Error(t, err)
+				} else {
+					testutil.NoError(t, err)
+				}
+			})
+
+			t.Run("decoder", func(t *testing.T) {
+				var mock Mock
+
+				err := NewDecoder(strings.NewReader(tc.yaml)).Decode(&mock)
+
+				if tc.wantErr {
+					testutil.Error(t, err)
+				} else {
+					testutil.NoError(t, err)
+				}
+			})
+		})
+	}
+}
+
+func TestUnmarshal_position(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		yaml         string
+		line, column int
+		path         string
+	}{
+		{
+			name:   "invalid",
+			yaml:   "foo: x\nbar: -2\n",
+			line:   2,
+			column: 6,
+			path:   ".Bar",
+		},
+		{
+			name:   "nested invalid",
+			yaml:   "items:\n  - name: apple\n  - name: pear\n    count: -1\n",
+			line:   4,
+			column: 12,
+			path:   ".Items[1].Count",
+		},
+		{
+			name:   "nested missing",
+			yaml:   "items:\n  - name: apple\n  - count: 1\n",
+			line:   3,
+			column: 5,
+			path:   ".Items[1].Name",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var yamlErr Error
+
+			err := Unmarshal([]byte(tc.yaml), new(Mock))
+
+			testutil.Equal(t, true, errors.As(err, &yamlErr))
+			testutil.Equal(t, tc.line, yamlErr.Line)
+			testutil.Equal(t, tc.column, yamlErr.Column)
+			testutil.Equal(t, tc.path, yamlErr.Path())
+		})
+	}
+}
+
+func TestUnmarshal_null(t *testing.T) {
+	type Nullable struct {
+		Name  optional.Any[string] `yaml:"name"`
+		Title required.Any[string] `yaml:"title"`
+	}
+
+	var v Nullable
+
+	testutil.NoError(t, Unmarshal([]byte(`{name: "", title: ""}`), &v))
+	testutil.Equal(t, true, v.Name.HasValue())
+	testutil.Equal(t, "", v.Name.Must())
+
+	v = Nullable{}
+
+	testutil.NoError(t, Unmarshal([]byte(`{name: null, title: x}`), &v))
+	testutil.Equal(t, false, v.Name.HasValue())
+
+	v = Nullable{}
+
+	testutil.NoError(t, Unmarshal([]byte(`{title: x}`), &v))
+	testutil.Equal(t, false, v.Name.HasValue())
+
+	testutil.Error(t, Unmarshal([]byte(`{title: null}`), new(Nullable)))
+	testutil.Error(t, Unmarshal([]byte(`{name: x}`), new(Nullable)))
+}
@@ -0,0 +1,32 @@
+// Code generated by schemagen; DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	validate "github.com/metafates/schema/validate"
+)
+
+// Ensure that [AddressBook] type was not changed
+func _() {
+	type locked struct {
+		People []Person `json:"people"`
+	}
+	var v AddressBook
+	// Compiler error signifies that the type definition have changed.
+	// Re-run the schemagen command to regenerate this file.
+	_ = locked(v)
+}
+
+// TypeValidate implements the [validate.TypeValidateable] interface.
+func (x *AddressBook) TypeValidate() error {
+	for i0 := range x.People {
+		{
+			err0 := validate.Validate(&x.People[i0])
+			if err0 != nil {
+				return validate.ValidationError{Inner: err0}.WithPath(fmt.Sprintf(".People[%v]", i0))
+			}
+		}
+	}
+	return nil
+}
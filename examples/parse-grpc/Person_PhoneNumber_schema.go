@@ -0,0 +1,34 @@
+// Code generated by schemagen; DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"github.com/metafates/schema/required"
+	"github.com/metafates/schema/validate"
+)
+
+// Ensure that [Person_PhoneNumber] type was not changed
+func _() {
+	type locked struct {
+		Number required.Custom[string, validate.NonZero[string]] `json:"number"`
+		Type   Person_PhoneType                                  `json:"type"`
+	}
+	var v Person_PhoneNumber
+	// Compiler error signifies that the type definition have changed.
+	// Re-run the schemagen command to regenerate this file.
+	_ = locked(v)
+}
+
+// TypeValidate implements the [validate.TypeValidateable] interface.
+func (x *Person_PhoneNumber) TypeValidate() error {
+	err0 := validate.Validate(&x.Number)
+	if err0 != nil {
+		return validate.ValidationError{Inner: err0}.WithPath(fmt.Sprintf(".Number"))
+	}
+	err1 := validate.Validate(&x.Type)
+	if err1 != nil {
+		return validate.ValidationError{Inner: err1}.WithPath(fmt.Sprintf(".Type"))
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+// Code generated by schemagen; DO NOT EDIT.
+
+package main
+
+import (
+	"fmt"
+	"github.com/metafates/schema/optional"
+	"github.com/metafates/schema/required"
+	"github.com/metafates/schema/validate"
+)
+
+// Ensure that [Person] type was not changed
+func _() {
+	type locked struct {
+		Name   required.Custom[string, validate.NonZero[string]] `json:"name"`
+		Id     required.Custom[int32, validate.Positive0[int32]] `json:"id"`
+		Email  optional.Custom[string, validate.Email[string]]   `json:"email"`
+		Phones []Person_PhoneNumber                              `json:"phones"`
+	}
+	var v Person
+	// Compiler error signifies that the type definition have changed.
+	// Re-run the schemagen command to regenerate this file.
+	_ = locked(v)
+}
+
+// TypeValidate implements the [validate.TypeValidateable] interface.
+func (x *Person) TypeValidate() error {
+	err0 := validate.Validate(&x.Name)
+	if err0 != nil {
+		return validate.ValidationError{Inner: err0}.WithPath(fmt.Sprintf(".Name"))
+	}
+	err1 := validate.Validate(&x.Id)
+	if err1 != nil {
+		return validate.ValidationError{Inner: err1}.WithPath(fmt.Sprintf(".Id"))
+	}
+	err2 := validate.Validate(&x.Email)
+	if err2 != nil {
+		return validate.ValidationError{Inner: err2}.WithPath(fmt.Sprintf(".Email"))
+	}
+	for i0 := range x.Phones {
+		{
+			err3 := validate.Validate(&x.Phones[i0])
+			if err3 != nil {
+				return validate.ValidationError{Inner: err3}.WithPath(fmt.Sprintf(".Phones[%v]", i0))
+			}
+		}
+	}
+	return nil
+}
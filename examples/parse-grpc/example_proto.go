@@ -0,0 +1,37 @@
+// Code generated by schemagen; DO NOT EDIT.
+// source: example.proto
+
+package main
+
+import (
+	optional "github.com/metafates/schema/optional"
+	required "github.com/metafates/schema/required"
+)
+
+// Person is generated from pb.Person.
+type Person struct {
+	Name   required.NonZero[string]  `json:"name"`
+	Id     required.Positive0[int32] `json:"id"`
+	Email  optional.Email[string]    `json:"email"`
+	Phones []Person_PhoneNumber      `json:"phones"`
+}
+
+// Person_PhoneType is generated from pb.Person.PhoneType.
+type Person_PhoneType int32
+
+const (
+	Person_MOBILE Person_PhoneType = 0
+	Person_HOME   Person_PhoneType = 1
+	Person_WORK   Person_PhoneType = 2
+)
+
+// Person_PhoneNumber is generated from pb.Person.PhoneNumber.
+type Person_PhoneNumber struct {
+	Number required.NonZero[string] `json:"number"`
+	Type   Person_PhoneType         `json:"type"`
+}
+
+// Our address book file is just one of these.
+type AddressBook struct {
+	People []Person `json:"people"`
+}
@@ -4,35 +4,12 @@ import (
 	"fmt"
 	"log"
 
+	schemaproto "github.com/metafates/schema/encoding/proto"
 	"github.com/metafates/schema/examples/parse-grpc/pb"
-	"github.com/metafates/schema/optional"
 	"github.com/metafates/schema/parse"
-	"github.com/metafates/schema/required"
 )
 
-type AddressBook struct {
-	People []Person
-}
-
-type Person struct {
-	Name   required.NonZero[string]
-	Id     required.Positive0[int32]
-	Email  optional.Email[string]
-	Phones []PhoneNumber
-}
-
-type PhoneType int
-
-const (
-	PhoneTypeMobile = iota
-	PhoneTypeHome
-	PhoneTypeWork
-)
-
-type PhoneNumber struct {
-	Type   optional.Any[PhoneType]
-	Number required.NonZero[string]
-}
+//go:generate go run ../../cmd/schemagen -from-proto pb/example.binpb .
 
 func main() {
 	options := []parse.Option{
@@ -43,7 +20,7 @@ func main() {
 	{
 		var book AddressBook
 
-		err := parse.Parse(pb.AddressBook{
+		err := schemaproto.Parse(&pb.AddressBook{
 			People: []*pb.Person{
 				{
 					Name:  "Example Name",
@@ -80,17 +57,17 @@ func main() {
 		// 3
 
 		fmt.Printf(
-			"pb.Person_MOBILE == PhoneTypeMobile = %v\n",
-			book.People[0].Phones[1].Type.Must() == PhoneTypeMobile,
+			"pb.Person_MOBILE == Person_MOBILE = %v\n",
+			book.People[0].Phones[1].Type == Person_MOBILE,
 		)
-		// pb.Person_MOBILE == PhoneTypeMobile = true
+		// pb.Person_MOBILE == Person_MOBILE = true
 	}
 
 	// now let's try to trigger error by violating the schema
 	{
 		var book AddressBook
 
-		err := parse.Parse(pb.AddressBook{
+		err := schemaproto.Parse(&pb.AddressBook{
 			People: []*pb.Person{
 				{
 					Name:  "Example Name",
@@ -113,6 +90,6 @@ func main() {
 			},
 		}, &book, options...)
 
-		fmt.Println(err) // [0].Email: mail: no angle-addr
+		fmt.Println(err) // parse: .People[0].Email: validate: mail: no angle-addr
 	}
 }
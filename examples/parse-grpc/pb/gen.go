@@ -1,3 +1,3 @@
 package pb
 
-//go:generate protoc --go_out=. --go_opt=paths=source_relative example.proto
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --descriptor_set_out=example.binpb --include_source_info example.proto
@@ -4,11 +4,17 @@ go 1.24.2
 
 require (
 	github.com/dave/jennifer v1.7.1
+	github.com/fxamacker/cbor/v2 v2.9.2
+	github.com/vmihailenco/msgpack/v5 v5.4.1
+	golang.org/x/text v0.24.0
 	golang.org/x/tools v0.32.0
 	google.golang.org/protobuf v1.36.6
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
+	github.com/vmihailenco/tagparser/v2 v2.0.0 // indirect
+	github.com/x448/float16 v0.8.4 // indirect
 	golang.org/x/mod v0.24.0 // indirect
 	golang.org/x/sync v0.13.0 // indirect
 )
@@ -0,0 +1,227 @@
+// Package bincodec converts values to and from compact binary form, as [encoding.BinaryMarshaler]
+// and [encoding.BinaryUnmarshaler] do.
+package bincodec
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"math"
+	"reflect"
+)
+
+// Marshal encodes value:
+//   - T is encoded with its own [encoding.BinaryMarshaler], if implemented (e.g. [time.Time]).
+//   - Strings and byte slices are encoded as raw bytes.
+//   - Other basic kinds are encoded with fixed width in little endian order, int and uint take 8 bytes.
+//   - Anything else is encoded with gob.
+func Marshal[T any](value T) ([]byte, error) {
+	if m, ok := any(value).(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
+
+	v := reflect.ValueOf(&value).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), nil
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return marshalGob(value)
+		}
+
+		return append([]byte(nil), v.Bytes()...), nil
+
+	case reflect.Bool:
+		if v.Bool() {
+			return []byte{1}, nil
+		}
+
+		return []byte{0}, nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return appendUint(nil, uint64(v.Int()), size(v.Type())), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return appendUint(nil, v.Uint(), size(v.Type())), nil
+
+	case reflect.Float32:
+		return appendUint(nil, uint64(math.Float32bits(float32(v.Float()))), 4), nil
+
+	case reflect.Float64:
+		return appendUint(nil, math.Float64bits(v.Float()), 8), nil
+
+	case reflect.Complex64:
+		c := v.Complex()
+
+		data := appendUint(nil, uint64(math.Float32bits(float32(real(c)))), 4)
+
+		return appendUint(data, uint64(math.Float32bits(float32(imag(c)))), 4), nil
+
+	case reflect.Complex128:
+		c := v.Complex()
+
+		data := appendUint(nil, math.Float64bits(real(c)), 8)
+
+		return appendUint(data, math.Float64bits(imag(c)), 8), nil
+
+	default:
+		return marshalGob(value)
+	}
+}
+
+// Unmarshal decodes data encoded with [Marshal].
+func Unmarshal[T any](data []byte) (T, error) {
+	var value T
+
+	if u, ok := any(&value).(encoding.BinaryUnmarshaler); ok {
+		if err := u.UnmarshalBinary(data); err != nil {
+			return *new(T), err
+		}
+
+		return value, nil
+	}
+
+	v := reflect.ValueOf(&value).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(string(data))
+
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.Uint8 {
+			return unmarshalGob[T](data)
+		}
+
+		v.SetBytes(append([]byte(nil), data...))
+
+	case reflect.Bool:
+		if err := checkSize(v.Type(), data, 1); err != nil {
+			return *new(T), err
+		}
+
+		v.SetBool(data[0] != 0)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := readUint(v.Type(), data, size(v.Type()))
+		if err != nil {
+			return *new(T), err
+		}
+
+		// sign extension of the narrower types
+		shift := 64 - 8*size(v.Type())
+
+		v.SetInt(int64(n<<shift) >> shift)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := readUint(v.Type(), data, size(v.Type()))
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetUint(n)
+
+	case reflect.Float32:
+		n, err := readUint(v.Type(), data, 4)
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetFloat(float64(math.Float32frombits(uint32(n))))
+
+	case reflect.Float64:
+		n, err := readUint(v.Type(), data, 8)
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetFloat(math.Float64frombits(n))
+
+	case reflect.Complex64:
+		if err := checkSize(v.Type(), data, 8); err != nil {
+			return *new(T), err
+		}
+
+		re := math.Float32frombits(binary.LittleEndian.Uint32(data[:4]))
+		im := math.Float32frombits(binary.LittleEndian.Uint32(data[4:]))
+
+		v.SetComplex(complex(float64(re), float64(im)))
+
+	case reflect.Complex128:
+		if err := checkSize(v.Type(), data, 16); err != nil {
+			return *new(T), err
+		}
+
+		re := math.Float64frombits(binary.LittleEndian.Uint64(data[:8]))
+		im := math.Float64frombits(binary.LittleEndian.Uint64(data[8:]))
+
+		v.SetComplex(complex(re, im))
+
+	default:
+		return unmarshalGob[T](data)
+	}
+
+	return value, nil
+}
+
+// size returns the number of bytes the integer of type t is encoded with.
+func size(t reflect.Type) int {
+	switch t.Kind() {
+	case reflect.Int, reflect.Uint, reflect.Uintptr:
+		return 8
+
+	default:
+		return int(t.Size())
+	}
+}
+
+func appendUint(data []byte, n uint64, size int) []byte {
+	var buf [8]byte
+
+	binary.LittleEndian.PutUint64(buf[:], n)
+
+	return append(data, buf[:size]...)
+}
+
+func readUint(t reflect.Type, data []byte, size int) (uint64, error) {
+	if err := checkSize(t, data, size); err != nil {
+		return 0, err
+	}
+
+	var buf [8]byte
+
+	copy(buf[:], data)
+
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+func checkSize(t reflect.Type, data []byte, size int) error {
+	if len(data) != size {
+		return fmt.Errorf("invalid binary data for %s: expected %d bytes, got %d", t, size, len(data))
+	}
+
+	return nil
+}
+
+func marshalGob[T any](value T) ([]byte, error) {
+	var buf bytes.Buffer
+
+	if err := gob.NewEncoder(&buf).Encode(value); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func unmarshalGob[T any](data []byte) (T, error) {
+	var value T
+
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return *new(T), err
+	}
+
+	return value, nil
+}
@@ -0,0 +1,40 @@
+// Package cborcodec holds the CBOR decoding mode shared by schemacbor package and required and optional types.
+//
+// [cbor.Unmarshaler] does not receive the mode of the caller,
+// so the values of required and optional types are decoded with this mode instead.
+package cborcodec
+
+import (
+	"sync/atomic"
+
+	"github.com/fxamacker/cbor/v2"
+)
+
+var decMode atomic.Pointer[cbor.DecMode]
+
+// DecMode returns the decoding mode set by [SetDecMode] or the default one.
+func DecMode() cbor.DecMode {
+	if dm := decMode.Load(); dm != nil {
+		return *dm
+	}
+
+	dm, _ := cbor.DecOptions{}.DecMode()
+
+	return dm
+}
+
+// SetDecMode sets the decoding mode. Nil resets it to the default one.
+func SetDecMode(dm cbor.DecMode) {
+	if dm == nil {
+		decMode.Store(nil)
+
+		return
+	}
+
+	decMode.Store(&dm)
+}
+
+// Unmarshal decodes data into v with [DecMode].
+func Unmarshal(data []byte, v any) error {
+	return DecMode().Unmarshal(data, v)
+}
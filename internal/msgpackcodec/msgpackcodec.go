@@ -0,0 +1,51 @@
+// Package msgpackcodec decodes MessagePack values preserving the integer widths of the target type.
+package msgpackcodec
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/vmihailenco/msgpack/v5"
+)
+
+// Decode decodes the next value of type T from dec.
+//
+// Unlike [msgpack.Decoder.Decode], integers which overflow T are reported as errors instead of being truncated.
+func Decode[T any](dec *msgpack.Decoder) (T, error) {
+	var value T
+
+	v := reflect.ValueOf(&value).Elem()
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := dec.DecodeInt64()
+		if err != nil {
+			return value, err
+		}
+
+		if v.OverflowInt(n) {
+			return value, fmt.Errorf("msgpack: %d overflows %s", n, v.Type())
+		}
+
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := dec.DecodeUint64()
+		if err != nil {
+			return value, err
+		}
+
+		if v.OverflowUint(n) {
+			return value, fmt.Errorf("msgpack: %d overflows %s", n, v.Type())
+		}
+
+		v.SetUint(n)
+
+	default:
+		if err := dec.Decode(&value); err != nil {
+			return value, err
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,34 @@
+// Package naming converts Go names to other naming conventions.
+package naming
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Snake converts Go name to snake_case, e.g. "HTTPServerPort" is converted to "http_server_port".
+func Snake(name string) string {
+	runes := []rune(name)
+
+	var b strings.Builder
+
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				b.WriteByte('_')
+			}
+		}
+
+		b.WriteRune(unicode.ToLower(r))
+	}
+
+	return b.String()
+}
+
+// Kebab converts Go name to kebab-case, e.g. "HTTPServerPort" is converted to "http-server-port".
+func Kebab(name string) string {
+	return strings.ReplaceAll(Snake(name), "_", "-")
+}
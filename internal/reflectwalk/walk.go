@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // FieldVisitor defines a function signature for the callback.
@@ -11,6 +12,9 @@ import (
 type FieldVisitor func(path string, value reflect.Value) error
 
 // WalkFields traverses all fields in the given value, calling visitor for each field.
+//
+// Map values are visited through addressable copies.
+// A copy modified by the visitor is stored back into the map.
 func WalkFields(data any, visitor FieldVisitor) error {
 	// Track visited pointers to prevent infinite recursion on cycles.
 	visited := make(map[uintptr]bool)
@@ -82,7 +86,16 @@ func walkInterface(
 		return nil
 	}
 
-	return walkRecursive(path, v.Elem(), visitor, visited)
+	elem := v.Elem()
+
+	// Interface value is not addressable, walk its copy and store it back.
+	if v.CanSet() && elem.Kind() != reflect.Pointer {
+		elem = addressable(elem)
+
+		defer v.Set(elem)
+	}
+
+	return walkRecursive(path, elem, visitor, visited)
 }
 
 func walkStruct(
@@ -137,9 +150,19 @@ func walkMap(path string, v reflect.Value, visitor FieldVisitor, visited map[uin
 	keys := v.MapKeys()
 	for _, key := range keys {
 		valuePath := path + "[" + formatStr(key) + "]"
-		val := v.MapIndex(key)
 
-		if err := walkRecursive(valuePath, val, visitor, visited); err != nil {
+		// Map value is not addressable, walk its copy and store it back if it was modified,
+		// so that walking a map which is not modified does not write to it.
+		orig := v.MapIndex(key)
+		val := addressable(orig)
+
+		err := walkRecursive(valuePath, val, visitor, visited)
+
+		if v.CanInterface() && !reflect.DeepEqual(orig.Interface(), val.Interface()) {
+			v.SetMapIndex(key, val)
+		}
+
+		if err != nil {
 			return err
 		}
 	}
@@ -147,6 +170,14 @@ func walkMap(path string, v reflect.Value, visitor FieldVisitor, visited map[uin
 	return nil
 }
 
+// addressable returns an addressable copy of v.
+func addressable(v reflect.Value) reflect.Value {
+	c := reflect.New(v.Type()).Elem()
+	c.Set(v)
+
+	return c
+}
+
 func formatStr(v reflect.Value) string {
 	switch v.Kind() {
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
@@ -178,3 +209,14 @@ func formatStr(v reflect.Value) string {
 		return fmt.Sprint(v.Interface())
 	}
 }
+
+// IsNested reports whether path is the same as parent or points to a value nested in it,
+// e.g. ".Foo[0].Bar" is nested in ".Foo".
+func IsNested(path, parent string) bool {
+	rest, ok := strings.CutPrefix(path, parent)
+	if !ok {
+		return false
+	}
+
+	return rest == "" || rest[0] == '.' || rest[0] == '['
+}
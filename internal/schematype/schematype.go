@@ -0,0 +1,132 @@
+// Package schematype inspects schema types (e.g. required.Custom and optional.Custom) using reflection.
+package schematype
+
+import (
+	"encoding"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/metafates/schema/parse"
+)
+
+var parserType = reflect.TypeFor[parse.Parser]()
+
+// Target returns the type the value of type t is parsed into.
+// Pointers and schema types are unwrapped, e.g. *required.Custom[T, V] returns T.
+func Target(t reflect.Type) reflect.Type {
+	for t != nil {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+
+			continue
+		}
+
+		// NOTE: schema types return their value in Get method
+		get, ok := t.MethodByName("Get")
+		if !ok || get.Type.NumOut() == 0 || !reflect.PointerTo(t).Implements(parserType) {
+			return t
+		}
+
+		t = get.Type.Out(0)
+	}
+
+	return nil
+}
+
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
+// IsComposite reports whether t is a struct which fields are set separately, e.g. from different keys.
+// Structs without exported fields or implementing [encoding.TextUnmarshaler] (e.g. [time.Time]) are set as a whole.
+func IsComposite(t reflect.Type) bool {
+	if t == nil || t.Kind() != reflect.Struct || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		return false
+	}
+
+	for _, field := range reflect.VisibleFields(t) {
+		if field.IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Leaf is a field of a composite struct (see [IsComposite]), which is set as a whole.
+type Leaf struct {
+	// Path is the path of the field, e.g. ".Database.Host".
+	// Fields of embedded structs are promoted, e.g. ".Debug" instead of ".Base.Debug".
+	Path string
+
+	// Fields are the fields from the root struct to this one, embedded structs excluded.
+	Fields []reflect.StructField
+
+	// Type is the type the field is parsed into, see [Target].
+	Type reflect.Type
+}
+
+// Leaves returns the leaf fields of struct t and nested composite structs.
+// Fields with "-" value of the given tag are skipped and embedded structs with this tag set are not promoted.
+func Leaves(t reflect.Type, tag string) []Leaf {
+	return leaves(Target(t), tag, "", nil)
+}
+
+func leaves(t reflect.Type, tag, path string, parents []reflect.StructField) []Leaf {
+	if !IsComposite(t) {
+		return nil
+	}
+
+	var result []Leaf
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := Target(field.Type)
+
+		if field.Anonymous && name == "" && field.Type.Kind() == reflect.Struct && IsComposite(fieldType) {
+			result = append(result, leaves(fieldType, tag, path, parents)...)
+
+			continue
+		}
+
+		fieldPath := path + "." + field.Name
+		fields := append(slices.Clone(parents), field)
+
+		if IsComposite(fieldType) {
+			result = append(result, leaves(fieldType, tag, fieldPath, fields)...)
+
+			continue
+		}
+
+		result = append(result, Leaf{Path: fieldPath, Fields: fields, Type: fieldType})
+	}
+
+	return result
+}
+
+// Set sets the value at the path of Go field names (e.g. ".Database.Host") in the tree of maps,
+// which can be parsed into the struct with parse.Parse.
+func Set(tree map[string]any, path string, value any) {
+	names := strings.Split(strings.TrimPrefix(path, "."), ".")
+
+	for _, name := range names[:len(names)-1] {
+		child, ok := tree[name].(map[string]any)
+		if !ok {
+			child = make(map[string]any)
+			tree[name] = child
+		}
+
+		tree = child
+	}
+
+	tree[names[len(names)-1]] = value
+}
@@ -0,0 +1,162 @@
+// Package textcodec converts values to and from text, as [encoding.TextMarshaler] and [encoding.TextUnmarshaler] do.
+package textcodec
+
+import (
+	"encoding"
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Unmarshal converts text to the value of type T:
+//   - T is decoded with its own [encoding.TextUnmarshaler], if implemented.
+//   - Strings, byte and rune slices are set from raw text.
+//   - [time.Duration] is parsed with [time.ParseDuration].
+//   - Other basic kinds are parsed with strconv.
+//   - Anything else is decoded as json.
+func Unmarshal[T any](text []byte) (T, error) {
+	var value T
+
+	if u, ok := any(&value).(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText(text); err != nil {
+			return *new(T), err
+		}
+
+		return value, nil
+	}
+
+	v := reflect.ValueOf(&value).Elem()
+
+	if _, ok := any(value).(time.Duration); ok {
+		d, err := time.ParseDuration(string(text))
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetInt(int64(d))
+
+		return value, nil
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(string(text))
+
+	case reflect.Slice:
+		switch v.Type().Elem().Kind() {
+		case reflect.Uint8:
+			v.SetBytes(append([]byte(nil), text...))
+
+		case reflect.Int32:
+			v.Set(reflect.ValueOf([]rune(string(text))).Convert(v.Type()))
+
+		default:
+			return unmarshalJSON[T](text)
+		}
+
+	case reflect.Bool:
+		b, err := strconv.ParseBool(string(text))
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetBool(b)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(string(text), 10, v.Type().Bits())
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetInt(n)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(string(text), 10, v.Type().Bits())
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetUint(n)
+
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(string(text), v.Type().Bits())
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetFloat(f)
+
+	case reflect.Complex64, reflect.Complex128:
+		c, err := strconv.ParseComplex(string(text), v.Type().Bits())
+		if err != nil {
+			return *new(T), err
+		}
+
+		v.SetComplex(c)
+
+	default:
+		return unmarshalJSON[T](text)
+	}
+
+	return value, nil
+}
+
+func unmarshalJSON[T any](text []byte) (T, error) {
+	var value T
+
+	if err := json.Unmarshal(text, &value); err != nil {
+		return *new(T), err
+	}
+
+	return value, nil
+}
+
+// Marshal converts value to text. It is the reverse of [Unmarshal].
+func Marshal[T any](value T) ([]byte, error) {
+	if m, ok := any(value).(encoding.TextMarshaler); ok {
+		return m.MarshalText()
+	}
+
+	if d, ok := any(value).(time.Duration); ok {
+		return []byte(d.String()), nil
+	}
+
+	v := reflect.ValueOf(&value).Elem()
+
+	switch v.Kind() {
+	case reflect.String:
+		return []byte(v.String()), nil
+
+	case reflect.Slice:
+		switch v.Type().Elem().Kind() {
+		case reflect.Uint8:
+			return append([]byte(nil), v.Bytes()...), nil
+
+		case reflect.Int32:
+			//nolint:forcetypeassert // converted to this type
+			return []byte(string(v.Convert(reflect.TypeFor[[]rune]()).Interface().([]rune))), nil
+
+		default:
+			return json.Marshal(value)
+		}
+
+	case reflect.Bool:
+		return strconv.AppendBool(nil, v.Bool()), nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.AppendInt(nil, v.Int(), 10), nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.AppendUint(nil, v.Uint(), 10), nil
+
+	case reflect.Float32, reflect.Float64:
+		return strconv.AppendFloat(nil, v.Float(), 'g', -1, v.Type().Bits()), nil
+
+	case reflect.Complex64, reflect.Complex128:
+		return []byte(strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits())), nil
+
+	default:
+		return json.Marshal(value)
+	}
+}
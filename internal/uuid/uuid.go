@@ -89,3 +89,35 @@ func Validate(s string) error {
 
 	return nil
 }
+
+// Parse returns bytes of the UUID given in any format accepted by [Validate].
+func Parse(s string) ([16]byte, error) {
+	var id [16]byte
+
+	if err := Validate(s); err != nil {
+		return id, err
+	}
+
+	const standardLen = 36
+
+	switch len(s) {
+	case standardLen + 9:
+		s = s[9:]
+
+	case standardLen + 2:
+		s = s[1 : len(s)-1]
+	}
+
+	j := 0
+
+	for i := range id {
+		if s[j] == '-' {
+			j++
+		}
+
+		id[i] = xvalues[s[j]]<<4 | xvalues[s[j+1]]
+		j += 2
+	}
+
+	return id, nil
+}
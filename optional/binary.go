@@ -2,7 +2,9 @@ package optional
 
 import (
 	"encoding"
+	"errors"
 
+	"github.com/metafates/schema/internal/bincodec"
 	"github.com/metafates/schema/validate"
 )
 
@@ -12,11 +14,43 @@ var _ interface {
 } = (*Custom[any, validate.Validator[any]])(nil)
 
 // UnmarshalBinary implements the [encoding.BinaryUnmarshaler] interface.
+// See [Custom.MarshalBinary] for the format.
 func (c *Custom[T, V]) UnmarshalBinary(data []byte) error {
-	return c.GobDecode(data)
+	if len(data) == 0 {
+		return errors.New("UnmarshalBinary: no data")
+	}
+
+	// validated status will reset here
+	if data[0] == 0 {
+		*c = Custom[T, V]{}
+
+		return nil
+	}
+
+	value, err := bincodec.Unmarshal[T](data[1:])
+	if err != nil {
+		return err
+	}
+
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
 }
 
 // MarshalBinary implements the [encoding.BinaryMarshaler] interface.
+//
+// Missing value is encoded as a single zero byte. Present value is encoded after the presence byte (1)
+// with [encoding.BinaryMarshaler] of T, if implemented. Otherwise strings and byte slices are encoded as raw bytes,
+// other basic types are encoded with fixed width in little endian order and anything else is encoded with gob.
 func (c Custom[T, V]) MarshalBinary() ([]byte, error) {
-	return c.GobEncode()
+	if !c.hasValue {
+		return []byte{0}, nil
+	}
+
+	data, err := bincodec.Marshal(c.Must())
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte{1}, data...), nil
 }
@@ -0,0 +1,48 @@
+package optional
+
+import (
+	"github.com/fxamacker/cbor/v2"
+
+	"github.com/metafates/schema/internal/cborcodec"
+	"github.com/metafates/schema/validate"
+)
+
+var _ interface {
+	cbor.Unmarshaler
+	cbor.Marshaler
+} = (*Custom[any, validate.Validator[any]])(nil)
+
+// cborNull is the encoded CBOR null.
+var cborNull = []byte{0xf6}
+
+// UnmarshalCBOR implements the [cbor.Unmarshaler] interface.
+// Null and undefined result in a missing value.
+// Value is decoded into T directly, so that its integer and float widths are preserved.
+func (c *Custom[T, V]) UnmarshalCBOR(data []byte) error {
+	// validated status will reset here
+	if len(data) == 1 && (data[0] == 0xf6 || data[0] == 0xf7) {
+		*c = Custom[T, V]{}
+
+		return nil
+	}
+
+	var value T
+
+	// decode with the mode set in schemacbor package, as the mode of the caller is not known here
+	if err := cborcodec.Unmarshal(data, &value); err != nil {
+		return err
+	}
+
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
+}
+
+// MarshalCBOR implements the [cbor.Marshaler] interface.
+func (c Custom[T, V]) MarshalCBOR() ([]byte, error) {
+	if !c.hasValue {
+		return cborNull, nil
+	}
+
+	return cbor.Marshal(c.Must())
+}
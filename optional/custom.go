@@ -2,6 +2,10 @@
 //
 // Optional types support the following encoding/decoding formats:
 //   - json
+//   - yaml
+//   - xml
+//   - cbor
+//   - msgpack
 //   - sql
 //   - text
 //   - binary
@@ -12,17 +16,34 @@ import (
 	"reflect"
 
 	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/transform"
 	"github.com/metafates/schema/validate"
 )
 
 // Custom optional type.
 // When given non-null value it errors if validation fails.
+//
+// If V implements [transform.Transformer] (see [transform.Pipe]), the value is transformed before validation.
 type Custom[T any, V validate.Validator[T]] struct {
 	value     T
 	hasValue  bool
 	validated bool
 }
 
+// Of returns a validated optional value containing the given value.
+// Use the zero value of [Custom] for an empty one.
+//
+//	age, err := optional.Of[int, validate.Positive[int]](42)
+func Of[T any, V validate.Validator[T]](value T) (Custom[T, V], error) {
+	c := Custom[T, V]{value: value, hasValue: true}
+
+	if err := c.TypeValidate(); err != nil {
+		return Custom[T, V]{}, err
+	}
+
+	return c, nil
+}
+
 // TypeValidate implements the [validate.TypeValidateable] interface.
 // You should not call this function directly.
 func (c *Custom[T, V]) TypeValidate() error {
@@ -30,6 +51,8 @@ func (c *Custom[T, V]) TypeValidate() error {
 		return nil
 	}
 
+	c.value = transform.Apply[T, V](c.value)
+
 	if err := (*new(V)).Validate(c.value); err != nil {
 		return validate.ValidationError{Inner: err}
 	}
@@ -47,6 +70,9 @@ func (c *Custom[T, V]) TypeValidate() error {
 // HasValue returns the presence of the contained value.
 func (c Custom[T, V]) HasValue() bool { return c.hasValue }
 
+// IsZero reports whether the value is missing, so that fields tagged with `omitzero` are omitted when encoding json.
+func (c Custom[T, V]) IsZero() bool { return !c.hasValue }
+
 // Get returns the contained value and a boolean stating its presence.
 // True if value exists, false otherwise.
 //
@@ -102,6 +128,28 @@ func (c Custom[T, V]) Must() T {
 //
 // Passing nil results a valid empty instance.
 func (c *Custom[T, V]) Parse(value any) error {
+	return c.ParseWithOptions(value)
+}
+
+// ParseWithOptions is like [Custom.Parse], but accepts parsing options, e.g. [parse.WithCoercion].
+func (c *Custom[T, V]) ParseWithOptions(value any, options ...parse.Option) error {
+	var aux Custom[T, V]
+
+	if err := aux.set(value, options...); err != nil {
+		return err
+	}
+
+	if err := aux.TypeValidate(); err != nil {
+		return err
+	}
+
+	*c = aux
+
+	return nil
+}
+
+// set initializes this type with given value without validation.
+func (c *Custom[T, V]) set(value any, options ...parse.Option) error {
 	if value == nil {
 		*c = Custom[T, V]{}
 
@@ -119,33 +167,27 @@ func (c *Custom[T, V]) Parse(value any) error {
 	if _, ok := value.(interface{ isOptional() }); ok {
 		// NOTE: ensure this method name is in sync with [Custom.Get]
 		res := rValue.MethodByName("Get").Call(nil)
-		v, ok := res[0], res[1].Bool()
 
-		if !ok {
+		// [Defaulted.Get] always has a value
+		if len(res) == 2 && !res[1].Bool() {
 			*c = Custom[T, V]{}
 
 			return nil
 		}
 
-		rValue = v
+		rValue = res[0]
 	}
 
-	v, err := convert[T](rValue)
+	v, err := parse.Convert[T](rValue.Interface(), options...)
 	if err != nil {
-		return parse.ParseError{Inner: err}
+		return err
 	}
 
-	aux := Custom[T, V]{
+	*c = Custom[T, V]{
 		hasValue: true,
 		value:    v,
 	}
 
-	if err := aux.TypeValidate(); err != nil {
-		return err
-	}
-
-	*c = aux
-
 	return nil
 }
 
@@ -156,23 +198,3 @@ func (c *Custom[T, V]) MustParse(value any) {
 }
 
 func (Custom[T, V]) isOptional() {}
-
-func convert[T any](v reflect.Value) (T, error) {
-	tType := reflect.TypeFor[T]()
-
-	original := v
-
-	if v.Kind() == reflect.Pointer {
-		v = v.Elem()
-	}
-
-	if v.CanConvert(tType) {
-		//nolint:forcetypeassert // checked already by CanConvert
-		return v.Convert(tType).Interface().(T), nil
-	}
-
-	return *new(T), parse.UnconvertableTypeError{
-		Target:   tType.String(),
-		Original: original.Type().String(),
-	}
-}
@@ -0,0 +1,81 @@
+package optional
+
+import (
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/validate"
+)
+
+// Defaulter provides a default value of type T.
+// Like [validate.Validator], it's a special empty (struct{}) type that is invoked in a form of (*new(D)).Default().
+// Therefore it should not depend on inner state (fields).
+type Defaulter[T any] interface {
+	Default() T
+}
+
+// Defaulted optional type.
+// Like [Custom], but if value is missing (e.g. null, absent json field or NULL in sql)
+// it is set to the default value provided by D during validation.
+// The default value must pass the validation too.
+//
+//	type pageSize struct{}
+//
+//	func (pageSize) Default() int { return 20 }
+//
+//	var size optional.Defaulted[int, validate.Positive[int], pageSize]
+type Defaulted[T any, V validate.Validator[T], D Defaulter[T]] struct {
+	custom[T, V]
+}
+
+// TypeValidate implements the [validate.TypeValidateable] interface.
+// You should not call this function directly.
+func (d *Defaulted[T, V, D]) TypeValidate() error {
+	if !d.hasValue {
+		d.value = (*new(D)).Default()
+		d.hasValue = true
+	}
+
+	return d.custom.TypeValidate()
+}
+
+// Get returns the contained value, which is the default value if it was missing.
+// Panics if value was not validated yet.
+func (d Defaulted[T, V, D]) Get() T {
+	if !d.validated {
+		panic("called Get() on unvalidated value")
+	}
+
+	return d.value
+}
+
+// Parse checks if given value is valid.
+// If it is, a value is used to initialize this type.
+// See [Custom.Parse].
+//
+// Passing nil results the default value.
+func (d *Defaulted[T, V, D]) Parse(value any) error {
+	return d.ParseWithOptions(value)
+}
+
+// ParseWithOptions is like [Defaulted.Parse], but accepts parsing options.
+// See [Custom.ParseWithOptions].
+func (d *Defaulted[T, V, D]) ParseWithOptions(value any, options ...parse.Option) error {
+	var aux Defaulted[T, V, D]
+
+	if err := aux.set(value, options...); err != nil {
+		return err
+	}
+
+	if err := aux.TypeValidate(); err != nil {
+		return err
+	}
+
+	*d = aux
+
+	return nil
+}
+
+func (d *Defaulted[T, V, D]) MustParse(value any) {
+	if err := d.Parse(value); err != nil {
+		panic("MustParse failed")
+	}
+}
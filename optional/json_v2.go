@@ -0,0 +1,48 @@
+//go:build goexperiment.jsonv2 && go1.27
+
+package optional
+
+import (
+	"encoding/json/jsontext"
+	"encoding/json/v2"
+
+	"github.com/metafates/schema/validate"
+)
+
+var _ interface {
+	json.UnmarshalerFrom
+	json.MarshalerTo
+} = (*Custom[any, validate.Validator[any]])(nil)
+
+// UnmarshalJSONFrom implements the [json.UnmarshalerFrom] interface.
+// Null results in a missing value.
+// The value is decoded from the stream directly with the options of dec.
+func (c *Custom[T, V]) UnmarshalJSONFrom(dec *jsontext.Decoder) error {
+	// validated status will reset here
+	if dec.PeekKind() == 'n' {
+		*c = Custom[T, V]{}
+
+		_, err := dec.ReadToken()
+
+		return err
+	}
+
+	var value T
+
+	if err := json.UnmarshalDecode(dec, &value); err != nil {
+		return err
+	}
+
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
+}
+
+// MarshalJSONTo implements the [json.MarshalerTo] interface.
+func (c Custom[T, V]) MarshalJSONTo(enc *jsontext.Encoder) error {
+	if !c.hasValue {
+		return enc.WriteToken(jsontext.Null)
+	}
+
+	return json.MarshalEncode(enc, c.Must())
+}
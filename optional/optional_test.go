@@ -1,11 +1,24 @@
 package optional
 
 import (
+	"bytes"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"flag"
+	"net/url"
 	"reflect"
 	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/vmihailenco/msgpack/v5"
+	"gopkg.in/yaml.v3"
 
 	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/transform"
+	"github.com/metafates/schema/validate"
 )
 
 func TestCustom_Parse(t *testing.T) {
@@ -138,3 +151,537 @@ func TestOptional(t *testing.T) {
 		})
 	})
 }
+
+func TestCustom_transform(t *testing.T) {
+	type Email = Custom[string, transform.Pipe[string, transform.TrimLower[string], validate.Email[string]]]
+
+	t.Run("json", func(t *testing.T) {
+		var foo Email
+
+		testutil.NoError(t, json.Unmarshal([]byte(`" John@Example.COM "`), &foo))
+		testutil.NoError(t, foo.TypeValidate())
+		testutil.Equal(t, "john@example.com", foo.Must())
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		var foo Email
+
+		testutil.NoError(t, foo.Parse(" John@Example.COM "))
+		testutil.Equal(t, "john@example.com", foo.Must())
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		var foo Email
+
+		testutil.NoError(t, foo.Scan(" John@Example.COM "))
+		testutil.NoError(t, foo.TypeValidate())
+		testutil.Equal(t, "john@example.com", foo.Must())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var foo Email
+
+		testutil.Error(t, foo.Parse(" John Example "))
+	})
+}
+
+// shortURL refines any text into URL but validates its length, so that Refine alone is not enough.
+type shortURL struct{}
+
+func (shortURL) Validate(value string) error {
+	if len(value) > 20 {
+		return errors.New("too long")
+	}
+
+	return nil
+}
+
+func (shortURL) Refine(value string) (*url.URL, error) {
+	return url.Parse(value)
+}
+
+func TestRefined(t *testing.T) {
+	type URL = Refined[string, *url.URL, validate.HTTPURL[string]]
+
+	t.Run("parse", func(t *testing.T) {
+		var foo URL
+
+		testutil.NoError(t, foo.Parse("https://example.com/path"))
+		testutil.Equal(t, "https://example.com/path", foo.Must())
+		parsed, ok := foo.Parsed()
+		testutil.Equal(t, true, ok)
+		testutil.Equal(t, "example.com", parsed.Host)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var foo URL
+
+		testutil.NoError(t, json.Unmarshal([]byte(`"https://example.com"`), &foo))
+		testutil.Panic(t, func() { foo.Parsed() })
+
+		testutil.NoError(t, validate.Validate(&foo))
+		parsed, ok := foo.Parsed()
+		testutil.Equal(t, true, ok)
+		testutil.Equal(t, "example.com", parsed.Host)
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		var foo struct {
+			Site URL `json:"site"`
+		}
+
+		testutil.NoError(t, json.Unmarshal([]byte(`{"site":"ftp://example.com"}`), &foo))
+		testutil.Error(t, validate.Validate(&foo))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var foo URL
+
+		testutil.Error(t, foo.Parse("ftp://example.com"))
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		var foo Refined[string, *url.URL, shortURL]
+
+		testutil.NoError(t, foo.Parse("https://example.com"))
+		testutil.Error(t, foo.Parse("https://example.com/a/long/path"))
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var foo URL
+
+		testutil.NoError(t, foo.Parse(nil))
+
+		_, ok := foo.Parsed()
+		testutil.Equal(t, false, ok)
+	})
+}
+
+type pageSize struct{}
+
+func (pageSize) Default() int { return 20 }
+
+type negative struct{}
+
+func (negative) Default() int { return -1 }
+
+func TestDefaulted(t *testing.T) {
+	type PageSize = Defaulted[int, validate.Positive[int], pageSize]
+
+	t.Run("json missing", func(t *testing.T) {
+		var foo struct {
+			Size PageSize `json:"size"`
+		}
+
+		testutil.NoError(t, json.Unmarshal([]byte(`{}`), &foo))
+		testutil.NoError(t, validate.Validate(&foo))
+		testutil.Equal(t, 20, foo.Size.Get())
+	})
+
+	t.Run("json null", func(t *testing.T) {
+		var foo PageSize
+
+		testutil.NoError(t, json.Unmarshal([]byte(`null`), &foo))
+		testutil.Panic(t, func() { foo.Get() })
+		testutil.NoError(t, foo.TypeValidate())
+		testutil.Equal(t, 20, foo.Get())
+	})
+
+	t.Run("json value", func(t *testing.T) {
+		var foo PageSize
+
+		testutil.NoError(t, json.Unmarshal([]byte(`5`), &foo))
+		testutil.NoError(t, foo.TypeValidate())
+		testutil.Equal(t, 5, foo.Get())
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		var foo PageSize
+
+		testutil.NoError(t, foo.Parse(nil))
+		testutil.Equal(t, 20, foo.Get())
+
+		testutil.NoError(t, foo.Parse(7))
+		testutil.Equal(t, 7, foo.Get())
+
+		testutil.Error(t, foo.Parse(-7))
+		testutil.Equal(t, 7, foo.Get())
+	})
+
+	t.Run("scan", func(t *testing.T) {
+		var foo PageSize
+
+		testutil.NoError(t, foo.Scan(nil))
+		testutil.NoError(t, foo.TypeValidate())
+		testutil.Equal(t, 20, foo.Get())
+	})
+
+	t.Run("invalid default", func(t *testing.T) {
+		var foo Defaulted[int, validate.Positive[int], negative]
+
+		testutil.Error(t, foo.Parse(nil))
+		testutil.Panic(t, func() { foo.Get() })
+	})
+
+	t.Run("from optional", func(t *testing.T) {
+		var (
+			foo PageSize
+			src Positive[int]
+		)
+
+		testutil.NoError(t, src.Parse(nil))
+		testutil.NoError(t, foo.Parse(src))
+		testutil.Equal(t, 20, foo.Get())
+
+		testutil.NoError(t, src.Parse(foo))
+		testutil.Equal(t, 20, src.Must())
+	})
+}
+
+func TestCustom_ParseWithOptions(t *testing.T) {
+	var foo Defaulted[int, validate.Positive[int], pageSize]
+
+	testutil.Error(t, foo.Parse("42"))
+	testutil.NoError(t, foo.ParseWithOptions("42", parse.WithCoercion()))
+	testutil.Equal(t, 42, foo.Get())
+	testutil.NoError(t, foo.ParseWithOptions(nil, parse.WithCoercion()))
+	testutil.Equal(t, 20, foo.Get())
+}
+
+func TestOf(t *testing.T) {
+	foo, err := Of[int, validate.Positive[int]](42)
+	testutil.NoError(t, err)
+	testutil.Equal(t, 42, foo.Must())
+
+	_, err = Of[int, validate.Positive[int]](-42)
+	testutil.Error(t, err)
+}
+
+func TestCustom_text(t *testing.T) {
+	var name Any[string]
+
+	testutil.NoError(t, name.UnmarshalText([]byte("hello")))
+	testutil.NoError(t, validate.Validate(&name))
+	testutil.Equal(t, "hello", name.Must())
+
+	t.Run("empty", func(t *testing.T) {
+		// empty text is a missing value, even for strings
+		testutil.NoError(t, name.UnmarshalText([]byte("")))
+		testutil.NoError(t, validate.Validate(&name))
+		testutil.Equal(t, false, name.HasValue())
+
+		text, err := name.MarshalText()
+		testutil.NoError(t, err)
+		testutil.Equal(t, "", string(text))
+
+		var count Positive[int]
+
+		testutil.NoError(t, count.UnmarshalText(nil))
+		testutil.NoError(t, validate.Validate(&count))
+		testutil.Equal(t, false, count.HasValue())
+	})
+
+	var timeout Positive[time.Duration]
+
+	testutil.NoError(t, timeout.UnmarshalText([]byte("1m30s")))
+	testutil.NoError(t, validate.Validate(&timeout))
+	testutil.Equal(t, 90*time.Second, timeout.Must())
+
+	text, err := timeout.MarshalText()
+	testutil.NoError(t, err)
+	testutil.Equal(t, "1m30s", string(text))
+
+	var count Positive[int]
+
+	testutil.Error(t, count.UnmarshalText([]byte("many")))
+	testutil.NoError(t, count.UnmarshalText([]byte("-1")))
+	testutil.Error(t, validate.Validate(&count))
+}
+
+func TestCustom_flag(t *testing.T) {
+	var port Positive[int]
+
+	testutil.Equal(t, "", port.String())
+	testutil.NoError(t, port.Set("8080"))
+	testutil.Equal(t, "8080", port.String())
+	testutil.NoError(t, validate.Validate(&port))
+	testutil.Equal(t, 8080, port.Must())
+
+	testutil.NoError(t, port.Set(""))
+	testutil.Equal(t, false, port.HasValue())
+
+	for _, args := range [][]string{{"-verbose"}, {"-verbose=true"}} {
+		var verbose Any[bool]
+
+		flags := flag.NewFlagSet("test", flag.ContinueOnError)
+		flags.Var(&verbose, "verbose", "verbose output")
+
+		testutil.NoError(t, flags.Parse(args))
+		testutil.NoError(t, validate.Validate(&verbose))
+		testutil.Equal(t, true, verbose.Must())
+	}
+}
+
+func TestCustom_binary(t *testing.T) {
+	roundTrip := func(t *testing.T, src, dst interface {
+		MarshalBinary() ([]byte, error)
+		UnmarshalBinary([]byte) error
+	},
+	) {
+		t.Helper()
+
+		data, err := src.MarshalBinary()
+		testutil.NoError(t, err)
+		testutil.NoError(t, dst.UnmarshalBinary(data))
+	}
+
+	t.Run("string", func(t *testing.T) {
+		var src, dst Any[string]
+
+		src.MustParse("hello")
+		roundTrip(t, &src, &dst)
+		testutil.NoError(t, validate.Validate(&dst))
+		testutil.Equal(t, "hello", dst.Must())
+	})
+
+	t.Run("negative int8", func(t *testing.T) {
+		var src, dst Any[int8]
+
+		src.MustParse(int8(-5))
+		roundTrip(t, &src, &dst)
+		testutil.NoError(t, validate.Validate(&dst))
+		testutil.Equal(t, int8(-5), dst.Must())
+	})
+
+	t.Run("bytes", func(t *testing.T) {
+		var src, dst Any[[]byte]
+
+		src.MustParse([]byte{0, 1, 2})
+		roundTrip(t, &src, &dst)
+		testutil.NoError(t, validate.Validate(&dst))
+		testutil.DeepEqual(t, []byte{0, 1, 2}, dst.Must())
+	})
+
+	t.Run("time", func(t *testing.T) {
+		var src, dst Any[time.Time]
+
+		now := time.Now()
+
+		src.MustParse(now)
+		roundTrip(t, &src, &dst)
+		testutil.NoError(t, validate.Validate(&dst))
+		testutil.Equal(t, true, now.Equal(dst.Must()))
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type point struct{ X, Y float64 }
+
+		var src, dst Any[point]
+
+		src.MustParse(point{X: 1.5, Y: -2})
+		roundTrip(t, &src, &dst)
+		testutil.NoError(t, validate.Validate(&dst))
+		testutil.Equal(t, point{X: 1.5, Y: -2}, dst.Must())
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var src, dst Any[int]
+
+		dst.MustParse(1)
+		roundTrip(t, &src, &dst)
+		testutil.NoError(t, validate.Validate(&dst))
+		testutil.Equal(t, false, dst.HasValue())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var dst Any[int]
+
+		testutil.Error(t, dst.UnmarshalBinary(nil))
+		testutil.Error(t, dst.UnmarshalBinary([]byte{1, 2}))
+	})
+}
+
+func TestCustom_yaml(t *testing.T) {
+	type Mock struct {
+		Name  Any[string]   `yaml:"name"`
+		Count Positive[int] `yaml:"count"`
+		Tags  Any[[]string] `yaml:"tags"`
+	}
+
+	var mock Mock
+
+	testutil.NoError(t, yaml.Unmarshal([]byte("name: \"\"\ncount: null\ntags: [a, b]\n"), &mock))
+	testutil.NoError(t, validate.Validate(&mock))
+	testutil.Equal(t, "", mock.Name.Must())
+	testutil.Equal(t, false, mock.Count.HasValue())
+	testutil.DeepEqual(t, []string{"a", "b"}, mock.Tags.Must())
+
+	data, err := yaml.Marshal(mock)
+	testutil.NoError(t, err)
+	testutil.Equal(t, "name: \"\"\ncount: null\ntags:\n    - a\n    - b\n", string(data))
+}
+
+func TestCustom_xml(t *testing.T) {
+	type Mock struct {
+		Name Any[string]   `xml:"name"`
+		Size Positive[int] `xml:"size,attr"`
+	}
+
+	var mock Mock
+
+	testutil.NoError(t, xml.Unmarshal([]byte(`<mock><name></name></mock>`), &mock))
+	testutil.NoError(t, validate.Validate(&mock))
+	testutil.Equal(t, "", mock.Name.Must())
+	testutil.Equal(t, false, mock.Size.HasValue())
+
+	mock = Mock{}
+
+	testutil.NoError(t, xml.Unmarshal([]byte(`<mock size="-1"></mock>`), &mock))
+	testutil.Equal(t, false, mock.Name.HasValue())
+	testutil.Error(t, validate.Validate(&mock))
+}
+
+func TestCustom_cbor(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		data []byte
+	}{
+		{name: "null", data: []byte{0xf6}},
+		{name: "undefined", data: []byte{0xf7}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c Any[int]
+
+			c.MustParse(5)
+
+			testutil.NoError(t, c.UnmarshalCBOR(tc.data))
+			testutil.NoError(t, validate.Validate(&c))
+			testutil.Equal(t, false, c.HasValue())
+		})
+	}
+
+	t.Run("float32 width", func(t *testing.T) {
+		var src, dst Any[float32]
+
+		src.MustParse(float32(0.1))
+
+		data, err := src.MarshalCBOR()
+		testutil.NoError(t, err)
+		testutil.Equal(t, byte(0xfa), data[0])
+
+		testutil.NoError(t, dst.UnmarshalCBOR(data))
+		testutil.NoError(t, validate.Validate(&dst))
+		testutil.Equal(t, float32(0.1), dst.Must())
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var c Any[int]
+
+		data, err := c.MarshalCBOR()
+		testutil.NoError(t, err)
+		testutil.DeepEqual(t, []byte{0xf6}, data)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		data, err := cbor.Marshal(300)
+		testutil.NoError(t, err)
+
+		var c Any[int8]
+
+		testutil.Error(t, c.UnmarshalCBOR(data))
+	})
+
+	t.Run("unvalidated", func(t *testing.T) {
+		var c Any[int]
+
+		testutil.NoError(t, c.UnmarshalCBOR([]byte{0x05}))
+		testutil.Panic(t, func() { _, _ = c.MarshalCBOR() })
+	})
+}
+
+func TestCustom_msgpack(t *testing.T) {
+	decode := func(t *testing.T, v any, dst interface {
+		DecodeMsgpack(dec *msgpack.Decoder) error
+	},
+	) error {
+		t.Helper()
+
+		data, err := msgpack.Marshal(v)
+		testutil.NoError(t, err)
+
+		return dst.DecodeMsgpack(msgpack.NewDecoder(bytes.NewReader(data)))
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		var c Any[int]
+
+		c.MustParse(5)
+
+		testutil.NoError(t, decode(t, nil, &c))
+		testutil.NoError(t, validate.Validate(&c))
+		testutil.Equal(t, false, c.HasValue())
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		var c Any[int]
+
+		data, err := msgpack.Marshal(c)
+		testutil.NoError(t, err)
+		testutil.DeepEqual(t, []byte{0xc0}, data)
+	})
+
+	t.Run("overflow", func(t *testing.T) {
+		var (
+			i Any[int8]
+			u Any[uint8]
+		)
+
+		testutil.Error(t, decode(t, 300, &i))
+		testutil.Error(t, decode(t, 256, &u))
+
+		testutil.NoError(t, decode(t, -128, &i))
+		testutil.NoError(t, validate.Validate(&i))
+		testutil.Equal(t, int8(-128), i.Must())
+	})
+
+	t.Run("float32 width", func(t *testing.T) {
+		var src, dst Any[float32]
+
+		src.MustParse(float32(0.1))
+
+		data, err := msgpack.Marshal(src)
+		testutil.NoError(t, err)
+		testutil.Equal(t, byte(0xca), data[0])
+
+		testutil.NoError(t, msgpack.Unmarshal(data, &dst))
+		testutil.NoError(t, validate.Validate(&dst))
+		testutil.Equal(t, float32(0.1), dst.Must())
+	})
+
+	t.Run("unvalidated", func(t *testing.T) {
+		var c Any[int]
+
+		testutil.NoError(t, decode(t, 5, &c))
+		testutil.Panic(t, func() { _, _ = msgpack.Marshal(c) })
+	})
+}
+
+func TestCustom_IsZero(t *testing.T) {
+	type Mock struct {
+		Name Any[string] `json:"name,omitzero"`
+	}
+
+	var mock Mock
+
+	testutil.NoError(t, json.Unmarshal([]byte(`{"name":null}`), &mock))
+	testutil.NoError(t, validate.Validate(&mock))
+	testutil.Equal(t, true, mock.Name.IsZero())
+
+	data, err := json.Marshal(mock)
+	testutil.NoError(t, err)
+	testutil.Equal(t, `{}`, string(data))
+
+	testutil.NoError(t, json.Unmarshal([]byte(`{"name":""}`), &mock))
+	testutil.NoError(t, validate.Validate(&mock))
+	testutil.Equal(t, false, mock.Name.IsZero())
+}
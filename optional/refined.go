@@ -0,0 +1,99 @@
+package optional
+
+import (
+	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/transform"
+	"github.com/metafates/schema/validate"
+)
+
+// custom is an unexported alias of [Custom] so that embedded field is not exported.
+type custom[T any, V validate.Validator[T]] = Custom[T, V]
+
+// Refined optional type.
+// Like [Custom], but V also produces a refined value of type R during validation,
+// e.g. *url.URL for [validate.URL] or [netip.Addr] for [validate.IP].
+// Refined value is available through [Refined.Parsed] method, so that the raw value is not parsed twice.
+//
+//	var u optional.Refined[string, *url.URL, validate.URL[string]]
+type Refined[T, R any, V validate.Refiner[T, R]] struct {
+	custom[T, V]
+
+	refined R
+}
+
+// TypeValidate implements the [validate.TypeValidateable] interface.
+// You should not call this function directly.
+func (r *Refined[T, R, V]) TypeValidate() error {
+	if !r.hasValue {
+		r.refined = *new(R)
+
+		return nil
+	}
+
+	r.value = transform.Apply[T, V](r.value)
+
+	var validator V
+
+	// Refine may skip the checks of Validate, see [validate.Refiner]
+	if err := validator.Validate(r.value); err != nil {
+		return validate.ValidationError{Inner: err}
+	}
+
+	refined, err := validator.Refine(r.value)
+	if err != nil {
+		return validate.ValidationError{Inner: err}
+	}
+
+	// validate nested types recursively
+	if err := validate.Validate(&r.value); err != nil {
+		return err
+	}
+
+	r.refined = refined
+	r.validated = true
+
+	return nil
+}
+
+// Parsed returns the refined value and a boolean stating its presence.
+// True if value exists, false otherwise.
+//
+// Panics if value was not validated yet.
+func (r Refined[T, R, V]) Parsed() (R, bool) {
+	if r.hasValue && !r.validated {
+		panic("called Parsed() on non-empty unvalidated value")
+	}
+
+	return r.refined, r.hasValue
+}
+
+// Parse checks if given value is valid.
+// If it is, a value is used to initialize this type.
+// See [Custom.Parse].
+func (r *Refined[T, R, V]) Parse(value any) error {
+	return r.ParseWithOptions(value)
+}
+
+// ParseWithOptions is like [Refined.Parse], but accepts parsing options.
+// See [Custom.ParseWithOptions].
+func (r *Refined[T, R, V]) ParseWithOptions(value any, options ...parse.Option) error {
+	var aux Refined[T, R, V]
+
+	if err := aux.set(value, options...); err != nil {
+		return err
+	}
+
+	if err := aux.TypeValidate(); err != nil {
+		return err
+	}
+
+	*r = aux
+
+	return nil
+}
+
+func (r *Refined[T, R, V]) MustParse(value any) {
+	if err := r.Parse(value); err != nil {
+		panic("MustParse failed")
+	}
+}
@@ -3,6 +3,7 @@ package optional
 import (
 	"encoding"
 
+	"github.com/metafates/schema/internal/textcodec"
 	"github.com/metafates/schema/validate"
 )
 
@@ -12,11 +13,35 @@ var _ interface {
 } = (*Custom[any, validate.Validator[any]])(nil)
 
 // UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+//
+// Empty text results in a missing value, even if T is a string.
+// Otherwise text is decoded with [encoding.TextUnmarshaler] of T, if implemented,
+// strings and byte slices are set from raw text, [time.Duration] is parsed with [time.ParseDuration],
+// other basic types are parsed with strconv (e.g. [strconv.ParseInt]) and anything else is decoded as json.
 func (c *Custom[T, V]) UnmarshalText(data []byte) error {
-	return c.UnmarshalJSON(data)
+	// validated status will reset here
+	if len(data) == 0 {
+		*c = Custom[T, V]{}
+
+		return nil
+	}
+
+	value, err := textcodec.Unmarshal[T](data)
+	if err != nil {
+		return err
+	}
+
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
 }
 
 // MarshalText implements the [encoding.TextMarshaler] interface.
+// It is the reverse of [Custom.UnmarshalText], missing value results in empty text.
 func (c Custom[T, V]) MarshalText() ([]byte, error) {
-	return c.MarshalJSON()
+	if !c.hasValue {
+		return []byte{}, nil
+	}
+
+	return textcodec.Marshal(c.Must())
 }
@@ -0,0 +1,71 @@
+package optional
+
+import (
+	"encoding/xml"
+
+	"github.com/metafates/schema/internal/textcodec"
+	"github.com/metafates/schema/validate"
+)
+
+var _ interface {
+	xml.Unmarshaler
+	xml.Marshaler
+	xml.UnmarshalerAttr
+	xml.MarshalerAttr
+} = (*Custom[any, validate.Validator[any]])(nil)
+
+// UnmarshalXML implements the [xml.Unmarshaler] interface.
+// Present element results in a present value, even if it is empty.
+// Missing element results in a missing value.
+func (c *Custom[T, V]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value T
+
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+
+	// validated status will reset here
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
+}
+
+// MarshalXML implements the [xml.Marshaler] interface.
+// Missing value is omitted.
+func (c Custom[T, V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if !c.hasValue {
+		return nil
+	}
+
+	return e.EncodeElement(c.Must(), start)
+}
+
+// UnmarshalXMLAttr implements the [xml.UnmarshalerAttr] interface.
+// Present attribute results in a present value, even if it is empty.
+// Missing attribute results in a missing value.
+func (c *Custom[T, V]) UnmarshalXMLAttr(attr xml.Attr) error {
+	value, err := textcodec.Unmarshal[T]([]byte(attr.Value))
+	if err != nil {
+		return err
+	}
+
+	// validated status will reset here
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
+}
+
+// MarshalXMLAttr implements the [xml.MarshalerAttr] interface.
+// Missing value is omitted.
+func (c Custom[T, V]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	if !c.hasValue {
+		return xml.Attr{}, nil
+	}
+
+	text, err := textcodec.Marshal(c.Must())
+	if err != nil {
+		return xml.Attr{}, err
+	}
+
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
@@ -0,0 +1,42 @@
+package optional
+
+import (
+	"gopkg.in/yaml.v3"
+
+	"github.com/metafates/schema/validate"
+)
+
+var _ interface {
+	yaml.Unmarshaler
+	yaml.Marshaler
+} = (*Custom[any, validate.Validator[any]])(nil)
+
+// UnmarshalYAML implements the [yaml.Unmarshaler] interface.
+// Null results in a missing value, while empty string is a value (unlike [Custom.UnmarshalText]).
+func (c *Custom[T, V]) UnmarshalYAML(node *yaml.Node) error {
+	// validated status will reset here
+	if node.ShortTag() == "!!null" {
+		*c = Custom[T, V]{}
+
+		return nil
+	}
+
+	var value T
+
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
+}
+
+// MarshalYAML implements the [yaml.Marshaler] interface.
+func (c Custom[T, V]) MarshalYAML() (any, error) {
+	if !c.hasValue {
+		return nil, nil
+	}
+
+	return c.Must(), nil
+}
@@ -0,0 +1,143 @@
+package parse
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType     = reflect.TypeFor[time.Time]()
+	durationType = reflect.TypeFor[time.Duration]()
+)
+
+// coerce sets dst from src of a different kind, e.g. "42" to 42 or 42 to "42".
+// It reports false if there is no coercion defined between the types of src and dst.
+func coerce(src reflect.Value, dst reflect.Value) (bool, error) {
+	switch {
+	case dst.Type() == timeType && src.Kind() == reflect.String:
+		t, err := time.Parse(time.RFC3339, src.String())
+		if err != nil {
+			return true, err
+		}
+
+		dst.Set(reflect.ValueOf(t))
+
+		return true, nil
+
+	case src.Type() == timeType && dst.Kind() == reflect.String:
+		//nolint:forcetypeassert // checked by the case condition
+		dst.SetString(src.Interface().(time.Time).Format(time.RFC3339Nano))
+
+		return true, nil
+
+	case dst.Type() == durationType && src.Kind() == reflect.String:
+		d, err := time.ParseDuration(src.String())
+		if err != nil {
+			return true, err
+		}
+
+		dst.SetInt(int64(d))
+
+		return true, nil
+
+	case src.Type() == durationType && dst.Kind() == reflect.String:
+		dst.SetString(time.Duration(src.Int()).String())
+
+		return true, nil
+
+	case src.Kind() == reflect.String:
+		return coerceFromString(src.String(), dst)
+
+	case dst.Kind() == reflect.String:
+		return coerceToString(src, dst)
+	}
+
+	return false, nil
+}
+
+func coerceFromString(s string, dst reflect.Value) (bool, error) {
+	switch {
+	case isInt(dst.Kind()):
+		n, err := strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return true, err
+		}
+
+		dst.SetInt(n)
+
+	case isUint(dst.Kind()):
+		n, err := strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return true, err
+		}
+
+		dst.SetUint(n)
+
+	case isFloat(dst.Kind()):
+		f, err := strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return true, err
+		}
+
+		dst.SetFloat(f)
+
+	case dst.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return true, err
+		}
+
+		dst.SetBool(b)
+
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func coerceToString(src reflect.Value, dst reflect.Value) (bool, error) {
+	switch {
+	case isInt(src.Kind()):
+		dst.SetString(strconv.FormatInt(src.Int(), 10))
+
+	case isUint(src.Kind()):
+		dst.SetString(strconv.FormatUint(src.Uint(), 10))
+
+	case isFloat(src.Kind()):
+		dst.SetString(strconv.FormatFloat(src.Float(), 'f', -1, src.Type().Bits()))
+
+	case src.Kind() == reflect.Bool:
+		dst.SetString(strconv.FormatBool(src.Bool()))
+
+	default:
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func isInt(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return true
+
+	default:
+		return false
+	}
+}
+
+func isUint(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+
+	default:
+		return false
+	}
+}
+
+func isFloat(kind reflect.Kind) bool {
+	return kind == reflect.Float32 || kind == reflect.Float64
+}
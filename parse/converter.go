@@ -0,0 +1,109 @@
+package parse
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+type converterKey struct {
+	Src, Dst reflect.Type
+}
+
+// converter converts src into value of the dst type.
+type converter func(src reflect.Value) (reflect.Value, error)
+
+var (
+	convertersMu sync.RWMutex
+	converters   = make(map[converterKey]converter)
+
+	// hasConverters is set once any converter is registered, so that lookups are skipped otherwise.
+	hasConverters atomic.Bool
+)
+
+// RegisterConverter registers a global conversion function from S to T, e.g. from decimal string to custom decimal type.
+// It is used by [Parse] at any depth whenever S value is parsed into T,
+// including values of schema types (e.g. required.Custom[T, V]).
+//
+// Converters set by [WithConverter] option take precedence.
+// RegisterConverter is intended to be called on program initialization.
+func RegisterConverter[S, T any](convert func(S) (T, error)) {
+	convertersMu.Lock()
+	defer convertersMu.Unlock()
+
+	converters[newConverterKey[S, T]()] = newConverter(convert)
+
+	hasConverters.Store(true)
+}
+
+// WithConverter is an option that will use conversion function from S to T whenever S value is parsed into T.
+// See [RegisterConverter].
+func WithConverter[S, T any](convert func(S) (T, error)) Option {
+	return func(cfg *config) {
+		if cfg.Converters == nil {
+			cfg.Converters = make(map[converterKey]converter)
+		}
+
+		cfg.Converters[newConverterKey[S, T]()] = newConverter(convert)
+	}
+}
+
+func newConverterKey[S, T any]() converterKey {
+	return converterKey{Src: reflect.TypeFor[S](), Dst: reflect.TypeFor[T]()}
+}
+
+func newConverter[S, T any](convert func(S) (T, error)) converter {
+	return func(src reflect.Value) (reflect.Value, error) {
+		//nolint:forcetypeassert // converter is looked up by the src type
+		dst, err := convert(src.Interface().(S))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		return reflect.ValueOf(&dst).Elem(), nil
+	}
+}
+
+// converter returns conversion function from src to dst types, if any.
+func (cfg *config) converter(src, dst reflect.Type) (converter, bool) {
+	key := converterKey{Src: src, Dst: dst}
+
+	if convert, ok := cfg.Converters[key]; ok {
+		return convert, true
+	}
+
+	if !hasConverters.Load() {
+		return nil, false
+	}
+
+	convertersMu.RLock()
+	defer convertersMu.RUnlock()
+
+	convert, ok := converters[key]
+
+	return convert, ok
+}
+
+// parseWithConverter sets dst from src using registered converters.
+// It reports false if there is no converter for the src (or the value it points to) and dst types.
+func parseWithConverter(src reflect.Value, dst reflect.Value, cfg *config) (bool, error) {
+	convert, ok := cfg.converter(src.Type(), dst.Type())
+
+	if !ok && src.Kind() == reflect.Pointer && !src.IsNil() {
+		src = src.Elem()
+		convert, ok = cfg.converter(src.Type(), dst.Type())
+	}
+
+	if !ok {
+		return false, nil
+	}
+
+	value, err := convert(src)
+	if err != nil {
+		return true, err
+	}
+
+	dst.Set(value)
+
+	return true, nil
+}
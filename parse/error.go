@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+
+	"github.com/metafates/schema/validate"
 )
 
 // InvalidParseError describes an invalid argument passed to [Parse].
@@ -50,23 +52,13 @@ type ParseError struct {
 
 // Path returns the path to the value which raised this error.
 func (e ParseError) Path() string {
-	var recursive func(path []string, err error) []string
-
-	recursive = func(path []string, err error) []string {
-		var validationErr ParseError
-
-		if !errors.As(err, &validationErr) {
-			return path
-		}
-
-		if validationErr.path != "" {
-			path = append(path, validationErr.path)
-		}
+	var inner validate.PathError
 
-		return recursive(path, validationErr.Inner)
+	if errors.As(e.Inner, &inner) {
+		return e.path + inner.Path()
 	}
 
-	return strings.Join(recursive(nil, e), "")
+	return e.path
 }
 
 func (e ParseError) Error() string {
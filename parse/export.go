@@ -0,0 +1,93 @@
+package parse
+
+import (
+	"reflect"
+
+	"github.com/metafates/schema/validate"
+)
+
+// Export is the reverse of [Parse]. It copies data from schema value src into plain dst,
+// e.g. struct, map or protobuf message struct, which is useful for building responses.
+//
+// Schema types (e.g. required.Custom and optional.Custom) in src are unwrapped to their values,
+// missing optional values are not assigned, therefore left nil or zero in dst.
+//
+// Src is validated beforehand, so that its values can be accessed.
+// Fields are matched the same way as in [Parse] and the same options are accepted.
+func Export(src, dst any, options ...Option) error {
+	if src == nil {
+		return nil
+	}
+
+	// ensure src is validated without modifying it
+	v := reflect.New(reflect.TypeOf(src))
+	v.Elem().Set(reflect.ValueOf(src))
+
+	if err := validate.Validate(v.Interface()); err != nil {
+		return err
+	}
+
+	cfg := newConfig(options)
+	cfg.export = true
+
+	return parseRoot(v.Elem().Interface(), dst, &cfg)
+}
+
+var parserType = reflect.TypeFor[Parser]()
+
+// exported returns the value of schema type src (e.g. required.Custom) or src itself if it's not a schema type.
+// Missing optional value is returned as nil.
+func exported(src any) any {
+	v := reflect.Indirect(reflect.ValueOf(src))
+
+	if !v.IsValid() {
+		return src
+	}
+
+	// NOTE: schema types return their value in Get method
+	get := v.MethodByName("Get")
+
+	if !get.IsValid() || get.Type().NumIn() != 0 || get.Type().NumOut() == 0 ||
+		!reflect.PointerTo(v.Type()).Implements(parserType) {
+		return src
+	}
+
+	res := get.Call(nil)
+
+	// optional types report if value is present
+	if len(res) == 2 && res[1].Kind() == reflect.Bool && !res[1].Bool() {
+		return nil
+	}
+
+	return res[0].Interface()
+}
+
+// plainType returns the type of plain value t is exported into, when the exact type is unknown (e.g. any).
+// It returns nil if t is exported as is.
+func plainType(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Struct:
+		if hasUnexportedFields(t) {
+			return nil
+		}
+
+		return reflect.TypeFor[map[string]any]()
+
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nil
+		}
+
+		return reflect.TypeFor[[]any]()
+
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return reflect.TypeFor[map[string]any]()
+		}
+
+		return reflect.TypeFor[map[any]any]()
+
+	default:
+		return nil
+	}
+}
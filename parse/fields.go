@@ -0,0 +1,149 @@
+package parse
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// FieldMatching defines how src fields/keys are matched with dst fields.
+type FieldMatching int
+
+const (
+	// MatchExact matches names as is.
+	MatchExact FieldMatching = iota
+
+	// MatchCaseInsensitive matches names regardless of their case, e.g. "userid" and "UserID".
+	MatchCaseInsensitive
+
+	// MatchFold matches names regardless of their case, underscores and dashes,
+	// so that snake_case, kebab-case and camelCase names are matched, e.g. "user_id" and "UserID".
+	MatchFold
+)
+
+func (m FieldMatching) normalize(name string) string {
+	switch m {
+	case MatchCaseInsensitive:
+		return strings.ToLower(name)
+
+	case MatchFold:
+		return strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+
+	default:
+		return name
+	}
+}
+
+type fieldsKey struct {
+	Type     reflect.Type
+	Tag      string
+	Matching FieldMatching
+}
+
+// fieldsCache maps fieldsKey to field indexes by their normalized names.
+var fieldsCache sync.Map
+
+// fieldIndex returns index of the dst field matching given name.
+func fieldIndex(t reflect.Type, name string, cfg *config) ([]int, bool) {
+	key := fieldsKey{Type: t, Tag: cfg.FieldTag, Matching: cfg.FieldMatching}
+
+	fields, ok := fieldsCache.Load(key)
+	if !ok {
+		fields, _ = fieldsCache.LoadOrStore(key, structFields(t, cfg))
+	}
+
+	//nolint:forcetypeassert // only this type is stored
+	index, ok := fields.(map[string][]int)[cfg.FieldMatching.normalize(name)]
+
+	return index, ok
+}
+
+func structFields(t reflect.Type, cfg *config) map[string][]int {
+	fields := make(map[string][]int)
+
+	// names of ambiguous fields at the same depth, they are never matched (same thing [reflect.Value.FieldByName] does)
+	ambiguous := make(map[string]bool)
+
+	for _, field := range reflect.VisibleFields(t) {
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := fieldName(field, cfg.FieldTag)
+		if !ok {
+			continue
+		}
+
+		name = cfg.FieldMatching.normalize(name)
+
+		if index, ok := fields[name]; ok {
+			switch {
+			case len(index) < len(field.Index):
+				continue
+
+			case len(index) == len(field.Index):
+				ambiguous[name] = true
+
+				continue
+			}
+		}
+
+		delete(ambiguous, name)
+
+		fields[name] = field.Index
+	}
+
+	for name := range ambiguous {
+		delete(fields, name)
+	}
+
+	return fields
+}
+
+// fieldName returns the name of the field according to the given struct tag.
+// If the tag is missing, Go field name is used.
+// It reports false if the field should be skipped ("-" tag).
+func fieldName(field reflect.StructField, tag string) (string, bool) {
+	if tag == "" {
+		return field.Name, true
+	}
+
+	value, ok := field.Tag.Lookup(tag)
+	if !ok {
+		return field.Name, true
+	}
+
+	// e.g. `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3"`
+	if tag == "protobuf" {
+		var name string
+
+		for option := range strings.SplitSeq(value, ",") {
+			switch {
+			case strings.HasPrefix(option, "json="):
+				return strings.TrimPrefix(option, "json="), true
+
+			case strings.HasPrefix(option, "name="):
+				name = strings.TrimPrefix(option, "name=")
+			}
+		}
+
+		if name == "" {
+			return field.Name, true
+		}
+
+		return name, true
+	}
+
+	name, _, _ := strings.Cut(value, ",")
+
+	switch name {
+	case "-":
+		return "", false
+
+	case "":
+		return field.Name, true
+
+	default:
+		return name, true
+	}
+}
@@ -1,5 +1,12 @@
 package parse
 
+import (
+	"errors"
+
+	"github.com/metafates/schema/internal/reflectwalk"
+	"github.com/metafates/schema/validate"
+)
+
 func defaultConfig() config {
 	return config{
 		DisallowUnknownFields: false,
@@ -7,9 +14,40 @@ func defaultConfig() config {
 	}
 }
 
+func newConfig(options []Option) config {
+	cfg := defaultConfig()
+	cfg.options = options
+
+	for _, apply := range options {
+		apply(&cfg)
+	}
+
+	return cfg
+}
+
 type config struct {
 	DisallowUnknownFields bool
 	RenameFunc            RenameFunc
+	Coerce                bool
+	FieldTag              string
+	FieldMatching         FieldMatching
+	AllErrors             bool
+	Converters            map[converterKey]converter
+
+	// errs are collected if AllErrors is set.
+	errs validate.Errors
+
+	// failedPaths are paths of the values which failed to parse.
+	failedPaths []string
+
+	// export is set by [Export] to unwrap values of schema types in src.
+	export bool
+
+	// cache is set by [Plan].
+	cache *planCache
+
+	// options are passed to [ParserWithOptions] as is.
+	options []Option
 }
 
 type RenameFunc func(string) string
@@ -30,3 +68,101 @@ func WithRenameFunc(f RenameFunc) Option {
 		cfg.RenameFunc = f
 	}
 }
+
+// WithCoercion is an option that will convert values between different kinds, which are not convertible otherwise:
+//
+//   - string to number or bool and vice versa, e.g. "42" to 42
+//   - RFC 3339 string to [time.Time] and vice versa
+//   - duration string to [time.Duration] and vice versa, e.g. "1m30s"
+//
+// Without this option only direct conversions are allowed.
+func WithCoercion() Option {
+	return func(cfg *config) {
+		cfg.Coerce = true
+	}
+}
+
+// WithFieldTag is an option that will match src fields/keys with dst fields by the name in the given struct tag,
+// e.g. "json", "yaml" or "protobuf" (json_name is used), instead of Go field names.
+// Fields tagged with "-" are skipped. Fields without the tag are matched by Go field names.
+//
+// For struct src, its fields are named by the same tag.
+func WithFieldTag(tag string) Option {
+	return func(cfg *config) {
+		cfg.FieldTag = tag
+	}
+}
+
+// WithFieldMatching is an option that will match src fields/keys with dst fields using the given strategy.
+// See [FieldMatching].
+func WithFieldMatching(matching FieldMatching) Option {
+	return func(cfg *config) {
+		cfg.FieldMatching = matching
+	}
+}
+
+// WithAllErrors is an option that will not stop at the first error.
+// Instead, all unconvertible, unknown and invalid fields are reported in [validate.Errors].
+//
+// Fields which failed to parse are not validated.
+func WithAllErrors() Option {
+	return func(cfg *config) {
+		cfg.AllErrors = true
+	}
+}
+
+// fail returns err as is or collects it if [WithAllErrors] option is used, in which case nil is returned.
+func (cfg *config) fail(err ParseError) error {
+	if !cfg.AllErrors {
+		return err
+	}
+
+	cfg.failedPaths = append(cfg.failedPaths, err.path)
+
+	// errors collected by nested parsing, e.g. by [ParserWithOptions]
+	var errs validate.Errors
+
+	if errors.As(err.Inner, &errs) {
+		for _, e := range errs {
+			cfg.errs = append(cfg.errs, ParseError{Inner: e, path: err.path})
+		}
+
+		return nil
+	}
+
+	cfg.errs = append(cfg.errs, err)
+
+	return nil
+}
+
+// collected returns all collected errors combined with the given validation errors, if any.
+func (cfg *config) collected(validationErr error) error {
+	errs := cfg.errs
+
+	var validationErrs validate.Errors
+
+	if errors.As(validationErr, &validationErrs) {
+		for _, err := range validationErrs {
+			if !cfg.failed(err.Path()) {
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return errs
+}
+
+// failed reports whether value at the given path (or its parent) failed to parse.
+func (cfg *config) failed(path string) bool {
+	for _, failed := range cfg.failedPaths {
+		if reflectwalk.IsNested(path, failed) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -13,12 +13,19 @@ type Parser interface {
 	Parse(v any) error
 }
 
+// ParserWithOptions is a [Parser] which also accepts parsing options.
+// If implemented, it is preferred over [Parser].
+type ParserWithOptions interface {
+	ParseWithOptions(v any, options ...Option) error
+}
+
 // Parse attempts to copy data from src into dst. If dst implements the [Parser] interface,
 // Parse simply calls dst.Parse(src). Otherwise, it uses reflection to assign fields or
 // elements to dst. To succeed, dst must be a non-nil pointer to a settable value.
 //
-// The function supports struct-to-struct, map-to-struct, and slice-to-slice copying,
-// as well as direct conversions between basic types (including []byte to string).
+// The function supports struct-to-struct, map-to-struct, struct-to-map, map-to-map, and slice-to-slice (or array) copying,
+// allocation of pointers, assignment to interfaces, as well as direct conversions between basic types (including []byte to string).
+// Integers are not converted to strings, unless [WithCoercion] option is used.
 // If src is nil, no assignment is performed. If dst is not a valid pointer, an [InvalidParseError]
 // is returned. If a type conversion is not possible, an [UnconvertableTypeError] is returned.
 //
@@ -28,9 +35,35 @@ type Parser interface {
 //
 // Parse also accepts options. See [Option].
 func Parse(src, dst any, options ...Option) error {
+	cfg := newConfig(options)
+
+	return parseRoot(src, dst, &cfg)
+}
+
+func parseRoot(src, dst any, cfg *config) error {
+	if cfg.export {
+		src = exported(src)
+	}
+
+	if parser, ok := dst.(ParserWithOptions); ok {
+		if err := parser.ParseWithOptions(src, cfg.options...); err != nil {
+			if err := cfg.fail(ParseError{Inner: err}); err != nil {
+				return err
+			}
+
+			return cfg.collected(nil)
+		}
+
+		return nil
+	}
+
 	if parser, ok := dst.(Parser); ok {
 		if err := parser.Parse(src); err != nil {
-			return ParseError{Inner: err}
+			if err := cfg.fail(ParseError{Inner: err}); err != nil {
+				return err
+			}
+
+			return cfg.collected(nil)
 		}
 
 		return nil
@@ -42,14 +75,12 @@ func Parse(src, dst any, options ...Option) error {
 		return InvalidParseError{Type: v.Type()}
 	}
 
-	cfg := defaultConfig()
-
-	for _, apply := range options {
-		apply(&cfg)
+	if err := parse(src, v.Elem(), "", cfg); err != nil {
+		return err
 	}
 
-	if err := parse(src, v.Elem(), "", &cfg); err != nil {
-		return err
+	if cfg.AllErrors {
+		return cfg.collected(validate.ValidateAll(dst))
 	}
 
 	if err := validate.Validate(dst); err != nil {
@@ -59,17 +90,70 @@ func Parse(src, dst any, options ...Option) error {
 	return nil
 }
 
+// As is a generic shorthand for [Parse], which parses src into a new value of type T.
+//
+//	user, err := parse.As[User](data)
+func As[T any](src any, options ...Option) (T, error) {
+	var dst T
+
+	if err := Parse(src, &dst, options...); err != nil {
+		return *new(T), err
+	}
+
+	return dst, nil
+}
+
+// Convert converts src to the type T using the same rules as [Parse].
+// Unlike [Parse], the result is not validated.
+//
+// It is intended for the types implementing [ParserWithOptions].
+func Convert[T any](src any, options ...Option) (T, error) {
+	var dst T
+
+	cfg := newConfig(options)
+
+	if err := parse(src, reflect.ValueOf(&dst).Elem(), "", &cfg); err != nil {
+		return *new(T), err
+	}
+
+	if err := cfg.collected(nil); err != nil {
+		return *new(T), err
+	}
+
+	return dst, nil
+}
+
 func parse(src any, dst reflect.Value, dstPath string, cfg *config) error {
+	if cfg.export {
+		src = exported(src)
+	}
+
 	// If src is nil, we stop (do not set anything).
 	if src == nil {
 		return nil
 	}
 
+	if ok, err := parseWithConverter(reflect.ValueOf(src), dst, cfg); ok {
+		if err != nil {
+			return cfg.fail(ParseError{Inner: err, path: dstPath})
+		}
+
+		return nil
+	}
+
 	if dst.CanAddr() {
+		if parser, ok := dst.Addr().Interface().(ParserWithOptions); ok {
+			if err := parser.ParseWithOptions(src, cfg.options...); err != nil {
+				return cfg.fail(ParseError{Inner: err, path: dstPath})
+			}
+
+			return nil
+		}
+
 		if parser, ok := dst.Addr().Interface().(Parser); ok {
 			// Let the target type parse "src" however it likes
 			if err := parser.Parse(src); err != nil {
-				return ParseError{Inner: err, path: dstPath}
+				return cfg.fail(ParseError{Inner: err, path: dstPath})
 			}
 
 			return nil
@@ -86,21 +170,53 @@ func parse(src any, dst reflect.Value, dstPath string, cfg *config) error {
 		vSrc = vSrc.Elem()
 	}
 
+	if cfg.Coerce {
+		if ok, err := coerce(vSrc, dst); ok {
+			if err != nil {
+				return cfg.fail(ParseError{Inner: err, path: dstPath})
+			}
+
+			return nil
+		}
+	}
+
 	switch dst.Kind() {
 	case reflect.Struct:
+		// Unexported fields (e.g. of time.Time) can not be copied one by one, so such structs are converted as is
+		if hasUnexportedFields(dst.Type()) && vSrc.Type().ConvertibleTo(dst.Type()) {
+			dst.Set(vSrc.Convert(dst.Type()))
+
+			return nil
+		}
+
 		return parseToStruct(vSrc, dst, dstPath, cfg)
 
 	case reflect.Slice:
 		return parseToSlice(vSrc, dst, dstPath, cfg)
 
+	case reflect.Array:
+		return parseToArray(vSrc, dst, dstPath, cfg)
+
+	case reflect.Map:
+		return parseToMap(vSrc, dst, dstPath, cfg)
+
+	case reflect.Pointer:
+		return parseToPointer(vSrc, dst, dstPath, cfg)
+
+	case reflect.Interface:
+		return parseToInterface(src, dst, dstPath, cfg)
+
 	default:
-		return parseToBasic(vSrc, dst)
+		return parseToBasic(vSrc, dst, dstPath, cfg)
 	}
 }
 
-func parseToBasic(src reflect.Value, dst reflect.Value) error {
+func parseToBasic(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
+	// Integer to string conversion yields a rune, which is never intended.
+	unsafe := dst.Kind() == reflect.String && (isInt(src.Kind()) || isUint(src.Kind()))
+
 	// For basic types, try direct conversion.
-	if src.CanConvert(dst.Type()) {
+	if !unsafe && src.CanConvert(dst.Type()) {
 		dst.Set(src.Convert(dst.Type()))
 
 		return nil
@@ -115,12 +231,13 @@ func parseToBasic(src reflect.Value, dst reflect.Value) error {
 		return nil
 	}
 
-	return ParseError{
+	return cfg.fail(ParseError{
 		Inner: UnconvertableTypeError{
 			Target:   dst.Type().String(),
-			Original: reflect.TypeOf(src).String(),
+			Original: src.Type().String(),
 		},
-	}
+		path: dstPath,
+	})
 }
 
 func parseToStruct(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
@@ -133,38 +250,17 @@ func parseToStruct(src reflect.Value, dst reflect.Value, dstPath string, cfg *co
 		return parseStructToStruct(src, dst, dstPath, cfg)
 
 	default:
-		return ParseError{
-			Msg:  fmt.Sprintf("cannot set struct from %T", src),
+		return cfg.fail(ParseError{
+			Msg:  fmt.Sprintf("cannot set struct from %s", src.Type()),
 			path: dstPath,
-		}
+		})
 	}
 }
 
 func parseStructToStruct(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
 	// We can copy fields from one struct to the other if they match by name.
-	srcType := src.Type()
-
-	for i := range srcType.NumField() {
-		fieldSrc := srcType.Field(i)
-		if !fieldSrc.IsExported() {
-			continue
-		}
-
-		fieldName := cfg.RenameFunc(fieldSrc.Name)
-
-		fieldDst := dst.FieldByName(fieldName)
-
-		if !fieldDst.IsValid() || !fieldDst.CanSet() {
-			if cfg.DisallowUnknownFields {
-				return ParseError{
-					Inner: UnknownFieldError{Name: fieldName},
-				}
-			}
-
-			continue
-		}
-
-		if err := parse(src.Field(i).Interface(), fieldDst, dstPath+"."+fieldName, cfg); err != nil {
+	for _, mapping := range cfg.structMappings(src.Type(), dst.Type()) {
+		if err := parseField(src.Field(mapping.Src).Interface(), dst, mapping, dstPath, cfg); err != nil {
 			return err
 		}
 	}
@@ -178,28 +274,18 @@ func parseMapToStruct(src reflect.Value, dst reflect.Value, dstPath string, cfg
 		// We only handle string keys here.
 		keyStr, ok := mk.Interface().(string)
 		if !ok {
-			return ParseError{
+			err := cfg.fail(ParseError{
 				Msg:  fmt.Sprintf("map key %v is not a string, cannot set struct field", mk),
 				path: dstPath,
-			}
-		}
-
-		keyStr = cfg.RenameFunc(keyStr)
-
-		field := dst.FieldByName(keyStr)
-
-		// If not found or not settable, ignore.
-		if !field.IsValid() || !field.CanSet() {
-			if cfg.DisallowUnknownFields {
-				return ParseError{
-					Inner: UnknownFieldError{Name: keyStr},
-				}
+			})
+			if err != nil {
+				return err
 			}
 
 			continue
 		}
 
-		if err := parse(src.MapIndex(mk).Interface(), field, dstPath+"."+keyStr, cfg); err != nil {
+		if err := parseField(src.MapIndex(mk).Interface(), dst, cfg.keyMapping(dst.Type(), keyStr), dstPath, cfg); err != nil {
 			return err
 		}
 	}
@@ -207,20 +293,37 @@ func parseMapToStruct(src reflect.Value, dst reflect.Value, dstPath string, cfg
 	return nil
 }
 
+// parseField parses src into dst struct field according to the mapping.
+func parseField(src any, dst reflect.Value, mapping fieldMapping, dstPath string, cfg *config) error {
+	// If not found, ignore.
+	if mapping.Dst == nil {
+		if cfg.DisallowUnknownFields {
+			return cfg.fail(ParseError{
+				Inner: UnknownFieldError{Name: mapping.Name},
+				path:  dstPath + mapping.Path,
+			})
+		}
+
+		return nil
+	}
+
+	return parse(src, dst.FieldByIndex(mapping.Dst), dstPath+mapping.Path, cfg)
+}
+
 func parseToSlice(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
-	// If dst is a slice, src must be a slice too.
-	if src.Kind() != reflect.Slice {
-		return ParseError{
-			Msg:  fmt.Sprintf("cannot set slice from %T", src),
+	// If dst is a slice, src must be a slice or an array.
+	if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+		return cfg.fail(ParseError{
+			Msg:  fmt.Sprintf("cannot set slice from %s", src.Type()),
 			path: dstPath,
-		}
+		})
 	}
 
 	// Create a new slice of the appropriate type/length.
 	slice := reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
 
 	for i := range src.Len() {
-		if err := parse(src.Index(i).Interface(), slice.Index(i), "["+strconv.Itoa(i)+"]", cfg); err != nil {
+		if err := parse(src.Index(i).Interface(), slice.Index(i), dstPath+"["+strconv.Itoa(i)+"]", cfg); err != nil {
 			return err
 		}
 	}
@@ -229,3 +332,169 @@ func parseToSlice(src reflect.Value, dst reflect.Value, dstPath string, cfg *con
 
 	return nil
 }
+
+func parseToArray(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
+	// If dst is an array, src must be a slice or an array which fits into it.
+	if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+		return cfg.fail(ParseError{
+			Msg:  fmt.Sprintf("cannot set array from %s", src.Type()),
+			path: dstPath,
+		})
+	}
+
+	if src.Len() > dst.Len() {
+		return cfg.fail(ParseError{
+			Msg:  fmt.Sprintf("cannot set array of length %d from %d elements", dst.Len(), src.Len()),
+			path: dstPath,
+		})
+	}
+
+	// Remaining elements are zeroed.
+	array := reflect.New(dst.Type()).Elem()
+
+	for i := range src.Len() {
+		if err := parse(src.Index(i).Interface(), array.Index(i), dstPath+"["+strconv.Itoa(i)+"]", cfg); err != nil {
+			return err
+		}
+	}
+
+	dst.Set(array)
+
+	return nil
+}
+
+func parseToMap(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
+	// If dst is a map, src should be either a map or a struct.
+	switch src.Kind() {
+	case reflect.Map:
+		return parseMapToMap(src, dst, dstPath, cfg)
+
+	case reflect.Struct:
+		return parseStructToMap(src, dst, dstPath, cfg)
+
+	default:
+		return cfg.fail(ParseError{
+			Msg:  fmt.Sprintf("cannot set map from %s", src.Type()),
+			path: dstPath,
+		})
+	}
+}
+
+func parseMapToMap(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
+	if src.IsNil() {
+		return nil
+	}
+
+	m := reflect.MakeMapWithSize(dst.Type(), src.Len())
+
+	for _, mk := range src.MapKeys() {
+		keyPath := dstPath + "[" + fmt.Sprint(mk.Interface()) + "]"
+
+		key := reflect.New(dst.Type().Key()).Elem()
+
+		if err := parse(mk.Interface(), key, keyPath, cfg); err != nil {
+			return err
+		}
+
+		value := reflect.New(dst.Type().Elem()).Elem()
+
+		if err := parse(src.MapIndex(mk).Interface(), value, keyPath, cfg); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(key, value)
+	}
+
+	dst.Set(m)
+
+	return nil
+}
+
+func parseStructToMap(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
+	// We only handle string keys here.
+	if dst.Type().Key().Kind() != reflect.String {
+		return cfg.fail(ParseError{
+			Msg:  fmt.Sprintf("cannot set map with %s keys from struct", dst.Type().Key()),
+			path: dstPath,
+		})
+	}
+
+	mappings := cfg.structMappings(src.Type(), dst.Type())
+
+	m := reflect.MakeMapWithSize(dst.Type(), len(mappings))
+
+	for _, mapping := range mappings {
+		value := reflect.New(dst.Type().Elem()).Elem()
+
+		if err := parse(src.Field(mapping.Src).Interface(), value, dstPath+mapping.Path, cfg); err != nil {
+			return err
+		}
+
+		m.SetMapIndex(reflect.ValueOf(mapping.Name).Convert(dst.Type().Key()), value)
+	}
+
+	dst.Set(m)
+
+	return nil
+}
+
+func parseToPointer(src reflect.Value, dst reflect.Value, dstPath string, cfg *config) error {
+	// Parse into a new value, so that the original one pointed by src is never modified.
+	ptr := reflect.New(dst.Type().Elem())
+
+	if err := parse(src.Interface(), ptr.Elem(), dstPath, cfg); err != nil {
+		return err
+	}
+
+	dst.Set(ptr)
+
+	return nil
+}
+
+func parseToInterface(src any, dst reflect.Value, dstPath string, cfg *config) error {
+	// If dst already holds a non-nil pointer, parse into the value it points to (same thing [json.Unmarshal] does).
+	if !dst.IsNil() && dst.Elem().Kind() == reflect.Pointer && !dst.Elem().IsNil() {
+		return parse(src, dst.Elem().Elem(), dstPath, cfg)
+	}
+
+	vSrc := reflect.ValueOf(src)
+
+	// exported values are plain, so that they do not contain schema types
+	if cfg.export {
+		if t := plainType(reflect.Indirect(vSrc).Type()); t != nil && t.AssignableTo(dst.Type()) {
+			value := reflect.New(t).Elem()
+
+			if err := parse(src, value, dstPath, cfg); err != nil {
+				return err
+			}
+
+			dst.Set(value)
+
+			return nil
+		}
+	}
+
+	if !vSrc.Type().AssignableTo(dst.Type()) {
+		return cfg.fail(ParseError{
+			Inner: UnconvertableTypeError{
+				Target:   dst.Type().String(),
+				Original: vSrc.Type().String(),
+			},
+			path: dstPath,
+		})
+	}
+
+	dst.Set(vSrc)
+
+	return nil
+}
+
+func hasUnexportedFields(t reflect.Type) bool {
+	for i := range t.NumField() {
+		if !t.Field(i).IsExported() {
+			return true
+		}
+	}
+
+	return false
+}
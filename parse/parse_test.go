@@ -2,7 +2,10 @@ package parse_test
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -319,6 +322,533 @@ func TestParse(t *testing.T) {
 	})
 }
 
+func TestParse_containers(t *testing.T) {
+	type Item struct {
+		Name  required.Charset[string, charset.Print]
+		Count int
+	}
+
+	type Target struct {
+		Items  map[string]Item
+		Scores map[int]float64
+		Point  [2]int
+		Nested *Item
+		Any    any
+		List   []Item
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var dst Target
+
+		testutil.NoError(t, parse.Parse(map[string]any{
+			"Items": map[string]any{
+				"a": map[string]any{"Name": "apple", "Count": 2},
+				"b": struct{ Name string }{Name: "banana"},
+			},
+			"Scores": map[int]int{1: 10},
+			"Point":  []int{3, 4},
+			"Nested": map[string]any{"Name": "nested"},
+			"Any":    []string{"x"},
+		}, &dst))
+
+		testutil.Equal(t, "apple", dst.Items["a"].Name.Get())
+		testutil.Equal(t, 2, dst.Items["a"].Count)
+		testutil.Equal(t, "banana", dst.Items["b"].Name.Get())
+		testutil.DeepEqual(t, map[int]float64{1: 10}, dst.Scores)
+		testutil.Equal(t, [2]int{3, 4}, dst.Point)
+		testutil.Equal(t, "nested", dst.Nested.Name.Get())
+		testutil.DeepEqual(t, any([]string{"x"}), dst.Any)
+	})
+
+	t.Run("struct to map", func(t *testing.T) {
+		var dst map[string]int
+
+		testutil.NoError(t, parse.Parse(struct{ A, B int }{A: 1, B: 2}, &dst))
+		testutil.DeepEqual(t, map[string]int{"A": 1, "B": 2}, dst)
+	})
+
+	t.Run("pointer is not shared", func(t *testing.T) {
+		type Ptr struct{ Value *int }
+
+		n := 1
+		src := Ptr{Value: &n}
+
+		var dst Ptr
+
+		testutil.NoError(t, parse.Parse(src, &dst))
+		testutil.Equal(t, 1, *dst.Value)
+		testutil.Equal(t, false, src.Value == dst.Value)
+	})
+
+	t.Run("interface holding pointer", func(t *testing.T) {
+		var item Item
+
+		dst := struct{ Any any }{Any: &item}
+
+		testutil.NoError(t, parse.Parse(map[string]any{"Any": map[string]any{"Name": "x"}}, &dst))
+		testutil.Equal(t, "x", item.Name.Get())
+	})
+
+	for _, tc := range []struct {
+		name string
+		src  map[string]any
+		path string
+	}{
+		{
+			name: "invalid map value",
+			src:  map[string]any{"Items": map[string]any{"a": map[string]any{"Name": ""}}},
+			path: ".Items[a].Name",
+		},
+		{
+			name: "invalid map key",
+			src:  map[string]any{"Scores": map[string]int{"one": 1}},
+			path: ".Scores[one]",
+		},
+		{
+			name: "array overflow",
+			src:  map[string]any{"Point": []int{1, 2, 3}},
+			path: ".Point",
+		},
+		{
+			name: "invalid pointer",
+			src:  map[string]any{"Nested": map[string]any{"Count": "many"}},
+			path: ".Nested.Count",
+		},
+		{
+			name: "invalid slice element",
+			src:  map[string]any{"List": []any{map[string]any{"Count": "many"}}},
+			path: ".List[0].Count",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var dst Target
+
+			err := parse.Parse(tc.src, &dst)
+			testutil.Error(t, err)
+			testutil.Equal(t, true, strings.Contains(err.Error(), tc.path))
+		})
+	}
+}
+
+func TestParse_fieldMatching(t *testing.T) {
+	type Embedded struct {
+		Note string `json:"note"`
+	}
+
+	type Target struct {
+		Embedded
+
+		UserID   required.Any[int] `json:"user_id" yaml:"id"`
+		FullName string            `json:"name"`
+		Secret   string            `json:"-"`
+		Plain    int
+	}
+
+	for _, tc := range []struct {
+		name    string
+		src     any
+		options []parse.Option
+		want    Target
+		wantErr bool
+	}{
+		{
+			name: "json tag",
+			src: map[string]any{
+				"user_id": 1, "name": "john", "Secret": "x", "Plain": 2, "note": "n",
+			},
+			options: []parse.Option{parse.WithFieldTag("json")},
+			want: Target{
+				Embedded: Embedded{Note: "n"},
+				FullName: "john",
+				Plain:    2,
+			},
+		},
+		{
+			name:    "yaml tag",
+			src:     map[string]any{"id": 1},
+			options: []parse.Option{parse.WithFieldTag("yaml")},
+		},
+		{
+			name: "struct src with protobuf tag",
+			src: struct {
+				UserId int64  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3"`
+				Name   string `protobuf:"bytes,2,opt,name=full_name,proto3"`
+			}{UserId: 1, Name: "jane"},
+			options: []parse.Option{
+				parse.WithFieldTag("protobuf"),
+				parse.WithFieldMatching(parse.MatchFold),
+			},
+			want: Target{FullName: "jane"},
+		},
+		{
+			name:    "case insensitive",
+			src:     map[string]any{"userid": 1, "PLAIN": 2},
+			options: []parse.Option{parse.WithFieldMatching(parse.MatchCaseInsensitive)},
+			want:    Target{Plain: 2},
+		},
+		{
+			name:    "fold",
+			src:     map[string]any{"user-id": 1, "full_name": "john", "fullName": "john"},
+			options: []parse.Option{parse.WithFieldMatching(parse.MatchFold)},
+			want:    Target{FullName: "john"},
+		},
+		{
+			name:    "exact by default",
+			src:     map[string]any{"user_id": 1},
+			wantErr: true,
+		},
+		{
+			name: "skipped field is unknown",
+			src:  map[string]any{"user_id": 1, "Secret": "x"},
+			options: []parse.Option{
+				parse.WithFieldTag("json"),
+				parse.WithDisallowUnknownFields(),
+			},
+			wantErr: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var dst Target
+
+			err := parse.Parse(tc.src, &dst, tc.options...)
+
+			if tc.wantErr {
+				testutil.Error(t, err)
+
+				return
+			}
+
+			testutil.NoError(t, err)
+			testutil.Equal(t, 1, dst.UserID.Get())
+			testutil.Equal(t, tc.want.FullName, dst.FullName)
+			testutil.Equal(t, tc.want.Plain, dst.Plain)
+			testutil.Equal(t, tc.want.Note, dst.Note)
+			testutil.Equal(t, "", dst.Secret)
+		})
+	}
+}
+
+func TestParse_allErrors(t *testing.T) {
+	type Item struct {
+		Name required.Charset[string, charset.Print]
+	}
+
+	type Target struct {
+		Age   required.Positive[int]
+		Count int
+		Name  required.Charset[string, charset.Print]
+		Items []Item
+		Inner required.Any[Item]
+	}
+
+	src := map[string]any{
+		"Age":     "ten",
+		"Count":   "many",
+		"Name":    "\x00",
+		"Items":   []any{map[string]any{"Name": "ok"}, map[string]any{}},
+		"Inner":   map[string]any{"Name": 1},
+		"Unknown": true,
+	}
+
+	var dst Target
+
+	err := parse.Parse(src, &dst, parse.WithAllErrors(), parse.WithDisallowUnknownFields())
+	testutil.Error(t, err)
+
+	var errs validate.Errors
+
+	testutil.Equal(t, true, errors.As(err, &errs))
+
+	paths := make([]string, 0, len(errs))
+
+	for _, e := range errs {
+		paths = append(paths, e.Path())
+	}
+
+	slices.Sort(paths)
+
+	testutil.DeepEqual(t, []string{".Age", ".Count", ".Inner.Name", ".Items[1].Name", ".Name", ".Unknown"}, paths)
+
+	t.Run("first error by default", func(t *testing.T) {
+		var dst Target
+
+		err := parse.Parse(src, &dst, parse.WithDisallowUnknownFields())
+		testutil.Error(t, err)
+		testutil.Equal(t, false, errors.As(err, &errs))
+	})
+
+	t.Run("valid", func(t *testing.T) {
+		var dst Target
+
+		testutil.NoError(t, parse.Parse(map[string]any{
+			"Age":   1,
+			"Name":  "john",
+			"Inner": map[string]any{"Name": "jane"},
+		}, &dst, parse.WithAllErrors()))
+	})
+}
+
+func TestExport(t *testing.T) {
+	type Item struct {
+		Name required.Charset[string, charset.Print]
+	}
+
+	type Schema struct {
+		ID       required.Positive[int]
+		Nickname optional.Any[string]
+		Email    optional.Any[string]
+		Created  required.Any[time.Time]
+		Items    []Item
+		Tags     map[string]required.Any[string]
+		Nested   *Item
+	}
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var src Schema
+
+	testutil.NoError(t, parse.Parse(map[string]any{
+		"ID":       1,
+		"Nickname": "johnny",
+		"Created":  created,
+		"Items":    []map[string]any{{"Name": "apple"}},
+		"Tags":     map[string]string{"a": "b"},
+		"Nested":   map[string]any{"Name": "nested"},
+	}, &src))
+
+	t.Run("struct", func(t *testing.T) {
+		type Plain struct {
+			Id       int64 //nolint:revive // like in protobuf structs
+			Nickname *string
+			Email    string
+			Created  time.Time
+			Items    []struct{ Name string }
+			Tags     map[string]string
+			Nested   *struct{ Name string }
+		}
+
+		var dst Plain
+
+		testutil.NoError(t, parse.Export(src, &dst, parse.WithFieldMatching(parse.MatchCaseInsensitive)))
+
+		testutil.Equal(t, 1, dst.Id)
+		testutil.Equal(t, "johnny", *dst.Nickname)
+		testutil.Equal(t, "", dst.Email)
+		testutil.Equal(t, true, created.Equal(dst.Created))
+		testutil.DeepEqual(t, []struct{ Name string }{{Name: "apple"}}, dst.Items)
+		testutil.DeepEqual(t, map[string]string{"a": "b"}, dst.Tags)
+		testutil.Equal(t, "nested", dst.Nested.Name)
+	})
+
+	t.Run("map", func(t *testing.T) {
+		var dst map[string]any
+
+		testutil.NoError(t, parse.Export(&src, &dst, parse.WithRenameFunc(strings.ToLower)))
+
+		testutil.Equal(t, any(1), dst["id"])
+		testutil.Equal(t, any("johnny"), dst["nickname"])
+		testutil.Equal(t, nil, dst["email"])
+		testutil.DeepEqual(t, any([]any{map[string]any{"name": "apple"}}), dst["items"])
+		testutil.DeepEqual(t, any(map[string]any{"a": "b"}), dst["tags"])
+		testutil.DeepEqual(t, any(map[string]any{"name": "nested"}), dst["nested"])
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var dst map[string]any
+
+		testutil.Error(t, parse.Export(Schema{}, &dst))
+	})
+}
+
+type (
+	cents     int64
+	legacyID  string
+	accountID struct{ id string }
+)
+
+func parseCents(s string) (cents, error) {
+	whole, frac, _ := strings.Cut(s, ".")
+
+	n, err := strconv.ParseInt(whole+frac, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(frac) != 2 {
+		return 0, errors.New("expected 2 decimal places")
+	}
+
+	return cents(n), nil
+}
+
+func TestParse_converter(t *testing.T) {
+	parse.RegisterConverter(func(id legacyID) (accountID, error) {
+		if !strings.HasPrefix(string(id), "acc-") {
+			return accountID{}, errors.New("invalid id")
+		}
+
+		return accountID{id: strings.TrimPrefix(string(id), "acc-")}, nil
+	})
+
+	type Line struct {
+		Price required.Positive[cents]
+	}
+
+	type Target struct {
+		Total   cents
+		Account accountID
+		Lines   []Line
+		Tip     optional.Positive[cents]
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var dst Target
+
+		testutil.NoError(t, parse.Parse(map[string]any{
+			"Total":   "12.34",
+			"Account": legacyID("acc-42"),
+			"Lines":   []map[string]any{{"Price": "10.00"}, {"Price": "2.34"}},
+			"Tip":     "1.50",
+		}, &dst, parse.WithConverter(parseCents)))
+
+		testutil.Equal(t, 1234, dst.Total)
+		testutil.Equal(t, accountID{id: "42"}, dst.Account)
+		testutil.Equal(t, 1000, dst.Lines[0].Price.Get())
+		testutil.Equal(t, 234, dst.Lines[1].Price.Get())
+		testutil.Equal(t, 150, dst.Tip.Must())
+	})
+
+	for name, src := range map[string]map[string]any{
+		"conversion": {"Total": "12.3"},
+		"global":     {"Account": legacyID("42")},
+		"validation": {"Lines": []map[string]any{{"Price": "-1.00"}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var dst Target
+
+			testutil.Error(t, parse.Parse(src, &dst, parse.WithConverter(parseCents)))
+		})
+	}
+
+	t.Run("without option", func(t *testing.T) {
+		var dst Target
+
+		testutil.Error(t, parse.Parse(map[string]any{"Total": "12.34"}, &dst))
+	})
+}
+
+func TestParse_coercion(t *testing.T) {
+	type Target struct {
+		Count    int
+		Ratio    float32
+		Enabled  bool
+		Name     string
+		Created  time.Time
+		Timeout  time.Duration
+		Interval string
+		Age      required.Positive[int]
+		Limit    optional.Positive[uint8]
+	}
+
+	created := time.Date(2024, 1, 1, 12, 30, 0, 0, time.UTC)
+
+	t.Run("from strings", func(t *testing.T) {
+		var dst Target
+
+		testutil.NoError(t, parse.Parse(map[string]any{
+			"Count":   "42",
+			"Ratio":   "0.5",
+			"Enabled": "true",
+			"Created": "2024-01-01T12:30:00Z",
+			"Timeout": "1m30s",
+			"Age":     "18",
+			"Limit":   "200",
+		}, &dst, parse.WithCoercion()))
+
+		testutil.Equal(t, 42, dst.Count)
+		testutil.Equal(t, 0.5, dst.Ratio)
+		testutil.Equal(t, true, dst.Enabled)
+		testutil.Equal(t, true, created.Equal(dst.Created))
+		testutil.Equal(t, 90*time.Second, dst.Timeout)
+		testutil.Equal(t, 18, dst.Age.Get())
+		testutil.Equal(t, 200, dst.Limit.Must())
+	})
+
+	t.Run("to strings", func(t *testing.T) {
+		var dst struct {
+			Count, Ratio, Enabled, Created, Timeout string
+		}
+
+		testutil.NoError(t, parse.Parse(map[string]any{
+			"Count":   -42,
+			"Ratio":   0.25,
+			"Enabled": false,
+			"Created": created,
+			"Timeout": 2 * time.Second,
+		}, &dst, parse.WithCoercion()))
+
+		testutil.Equal(t, "-42", dst.Count)
+		testutil.Equal(t, "0.25", dst.Ratio)
+		testutil.Equal(t, "false", dst.Enabled)
+		testutil.Equal(t, "2024-01-01T12:30:00Z", dst.Created)
+		testutil.Equal(t, "2s", dst.Timeout)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for name, src := range map[string]any{
+			"int":      map[string]any{"Age": "18", "Count": "forty two"},
+			"overflow": map[string]any{"Age": "18", "Limit": "300"},
+			"time":     map[string]any{"Age": "18", "Created": "2024-01-01"},
+			"duration": map[string]any{"Age": "18", "Timeout": "soon"},
+			"validate": map[string]any{"Age": "-1"},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var dst Target
+
+				testutil.Error(t, parse.Parse(src, &dst, parse.WithCoercion()))
+			})
+		}
+	})
+
+	t.Run("strict by default", func(t *testing.T) {
+		var dst struct {
+			Count   int
+			Name    string
+			Created time.Time
+		}
+
+		testutil.Error(t, parse.Parse(map[string]any{"Count": "42"}, &dst))
+		testutil.Error(t, parse.Parse(map[string]any{"Created": "2024-01-01T12:30:00Z"}, &dst))
+
+		// integer is not converted to a rune
+		testutil.Error(t, parse.Parse(map[string]any{"Name": 65}, &dst))
+
+		testutil.NoError(t, parse.Parse(map[string]any{"Created": created}, &dst))
+		testutil.Equal(t, true, created.Equal(dst.Created))
+	})
+}
+
+func TestConvert(t *testing.T) {
+	n, err := parse.Convert[int]("42", parse.WithCoercion())
+	testutil.NoError(t, err)
+	testutil.Equal(t, 42, n)
+
+	_, err = parse.Convert[int]("42")
+	testutil.Error(t, err)
+}
+
+func TestAs(t *testing.T) {
+	friend, err := parse.As[Friend](map[string]any{
+		"ID":   "7f735045-c8d2-4a60-9184-0fc033c40a6a",
+		"Name": "jane",
+	})
+	testutil.NoError(t, err)
+	testutil.Equal(t, "jane", friend.Name.Get())
+
+	_, err = parse.As[Friend](map[string]any{"Name": "jane"})
+	testutil.Error(t, err)
+}
+
 type Friend struct {
 	ID   required.UUID[string]
 	Name required.Charset[string, charset.Print]
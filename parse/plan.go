@@ -0,0 +1,162 @@
+package parse
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Plan parses values with the given options.
+//
+// Unlike [Parse], it compiles mapping of fields per src and dst types once and reuses it afterwards,
+// which makes parsing of many values of the same types (e.g. lists of gRPC messages) faster.
+//
+// Plan is safe for concurrent use.
+type Plan struct {
+	options []Option
+}
+
+// NewPlan returns a new [Plan] with the given options.
+func NewPlan(options ...Option) *Plan {
+	return &Plan{
+		options: append(slices.Clone(options), withCache(new(planCache))),
+	}
+}
+
+// Parse is like [Parse] with the options of this plan.
+func (p *Plan) Parse(src, dst any) error {
+	return Parse(src, dst, p.options...)
+}
+
+// Export is like [Export] with the options of this plan.
+func (p *Plan) Export(src, dst any) error {
+	return Export(src, dst, p.options...)
+}
+
+// withCache is an option that will cache field mappings in the given cache.
+// It is passed to nested parsers (e.g. required.Custom) along with other options, so that they share the cache.
+func withCache(cache *planCache) Option {
+	return func(cfg *config) {
+		cfg.cache = cache
+	}
+}
+
+type planCache struct {
+	// structs maps structsKey to []fieldMapping
+	structs sync.Map
+
+	// keys maps keyKey to fieldMapping
+	keys sync.Map
+}
+
+type structsKey struct {
+	Src, Dst reflect.Type
+}
+
+type keyKey struct {
+	Dst reflect.Type
+	Key string
+}
+
+// fieldMapping maps src field or map key to dst field.
+type fieldMapping struct {
+	// Src is the index of the src struct field.
+	Src int
+
+	// Name is the name of the src field or map key after renaming.
+	Name string
+
+	// Dst is the index of the dst struct field. It is nil if there is no such field.
+	Dst []int
+
+	// Path is the path of the dst field or map key.
+	Path string
+}
+
+// structMappings returns mappings of exported src struct fields to dst struct fields or map keys.
+func (cfg *config) structMappings(src, dst reflect.Type) []fieldMapping {
+	if cfg.cache == nil {
+		return compileStructMappings(src, dst, cfg)
+	}
+
+	key := structsKey{Src: src, Dst: dst}
+
+	if mappings, ok := cfg.cache.structs.Load(key); ok {
+		//nolint:forcetypeassert // only this type is stored
+		return mappings.([]fieldMapping)
+	}
+
+	mappings := compileStructMappings(src, dst, cfg)
+
+	cfg.cache.structs.Store(key, mappings)
+
+	return mappings
+}
+
+func compileStructMappings(src, dst reflect.Type, cfg *config) []fieldMapping {
+	mappings := make([]fieldMapping, 0, src.NumField())
+
+	for i := range src.NumField() {
+		field := src.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, ok := fieldName(field, cfg.FieldTag)
+		if !ok {
+			continue
+		}
+
+		var mapping fieldMapping
+
+		if dst.Kind() == reflect.Struct {
+			mapping = compileKeyMapping(dst, name, cfg)
+		} else {
+			name = cfg.RenameFunc(name)
+
+			mapping = fieldMapping{Name: name, Path: "[" + name + "]"}
+		}
+
+		mapping.Src = i
+
+		mappings = append(mappings, mapping)
+	}
+
+	return mappings
+}
+
+// keyMapping returns mapping of the map key to dst struct field.
+func (cfg *config) keyMapping(dst reflect.Type, key string) fieldMapping {
+	if cfg.cache == nil {
+		return compileKeyMapping(dst, key, cfg)
+	}
+
+	cacheKey := keyKey{Dst: dst, Key: key}
+
+	if mapping, ok := cfg.cache.keys.Load(cacheKey); ok {
+		//nolint:forcetypeassert // only this type is stored
+		return mapping.(fieldMapping)
+	}
+
+	mapping := compileKeyMapping(dst, key, cfg)
+
+	// unknown keys are not cached, so that cache does not grow with arbitrary input
+	if mapping.Dst != nil {
+		cfg.cache.keys.Store(cacheKey, mapping)
+	}
+
+	return mapping
+}
+
+func compileKeyMapping(dst reflect.Type, key string, cfg *config) fieldMapping {
+	name := cfg.RenameFunc(key)
+
+	mapping := fieldMapping{Name: name, Path: "." + name}
+
+	if index, ok := fieldIndex(dst, name, cfg); ok {
+		mapping.Dst = index
+		mapping.Path = "." + dst.FieldByIndex(index).Name
+	}
+
+	return mapping
+}
@@ -2,6 +2,10 @@
 //
 // Required types support the following encoding/decoding formats:
 //   - json
+//   - yaml
+//   - xml
+//   - cbor
+//   - msgpack
 //   - sql
 //   - text
 //   - binary
@@ -12,6 +16,7 @@ import (
 	"reflect"
 
 	"github.com/metafates/schema/parse"
+	"github.com/metafates/schema/transform"
 	"github.com/metafates/schema/validate"
 )
 
@@ -22,12 +27,27 @@ var (
 
 // Custom required type.
 // Errors if value is missing or did not pass the validation.
+//
+// If V implements [transform.Transformer] (see [transform.Pipe]), the value is transformed before validation.
 type Custom[T any, V validate.Validator[T]] struct {
 	value     T
 	hasValue  bool
 	validated bool
 }
 
+// New returns a validated required value.
+//
+//	id, err := required.New[int, validate.Positive[int]](42)
+func New[T any, V validate.Validator[T]](value T) (Custom[T, V], error) {
+	c := Custom[T, V]{value: value, hasValue: true}
+
+	if err := c.TypeValidate(); err != nil {
+		return Custom[T, V]{}, err
+	}
+
+	return c, nil
+}
+
 // TypeValidate implements the [validate.TypeValidateable] interface.
 // You should not call this function directly.
 func (c *Custom[T, V]) TypeValidate() error {
@@ -35,6 +55,8 @@ func (c *Custom[T, V]) TypeValidate() error {
 		return ErrMissingValue
 	}
 
+	c.value = transform.Apply[T, V](c.value)
+
 	if err := (*new(V)).Validate(c.value); err != nil {
 		return validate.ValidationError{Inner: err}
 	}
@@ -66,6 +88,28 @@ func (c Custom[T, V]) Get() T {
 //
 // Parsed type is validated, therefore it is safe to call [Custom.Get] afterwards.
 func (c *Custom[T, V]) Parse(value any) error {
+	return c.ParseWithOptions(value)
+}
+
+// ParseWithOptions is like [Custom.Parse], but accepts parsing options, e.g. [parse.WithCoercion].
+func (c *Custom[T, V]) ParseWithOptions(value any, options ...parse.Option) error {
+	var aux Custom[T, V]
+
+	if err := aux.set(value, options...); err != nil {
+		return err
+	}
+
+	if err := aux.TypeValidate(); err != nil {
+		return err
+	}
+
+	*c = aux
+
+	return nil
+}
+
+// set initializes this type with given value without validation.
+func (c *Custom[T, V]) set(value any, options ...parse.Option) error {
 	if value == nil {
 		return ErrParseNilValue
 	}
@@ -80,34 +124,21 @@ func (c *Custom[T, V]) Parse(value any) error {
 		rValue = rValue.Elem()
 	}
 
-	tType := reflect.TypeFor[T]()
-
 	if _, ok := value.(interface{ isRequired() }); ok {
 		// NOTE: ensure this method name is in sync with [Custom.Get]
 		rValue = rValue.MethodByName("Get").Call(nil)[0]
 	}
 
-	if !rValue.CanConvert(tType) {
-		return parse.ParseError{
-			Inner: parse.UnconvertableTypeError{
-				Target:   tType.String(),
-				Original: rValue.Type().String(),
-			},
-		}
+	v, err := parse.Convert[T](rValue.Interface(), options...)
+	if err != nil {
+		return err
 	}
 
-	//nolint:forcetypeassert // checked already by CanConvert
-	aux := Custom[T, V]{
-		value:    rValue.Convert(tType).Interface().(T),
+	*c = Custom[T, V]{
+		value:    v,
 		hasValue: true,
 	}
 
-	if err := aux.TypeValidate(); err != nil {
-		return err
-	}
-
-	*c = aux
-
 	return nil
 }
 
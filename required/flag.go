@@ -0,0 +1,43 @@
+package required
+
+import (
+	"flag"
+	"fmt"
+	"reflect"
+
+	"github.com/metafates/schema/internal/textcodec"
+	"github.com/metafates/schema/validate"
+)
+
+var _ flag.Value = (*Custom[any, validate.Validator[any]])(nil)
+
+// Set implements the [flag.Value] interface.
+// String is decoded as text (see [Custom.UnmarshalText]) without validation,
+// therefore [validate.Validate] should be called after parsing flags.
+//
+// NOTE: [flag.Getter] is not implemented, since it conflicts with [Custom.Get].
+// Use encoding/flag package, which also implements it.
+func (c *Custom[T, V]) Set(s string) error {
+	return c.UnmarshalText([]byte(s))
+}
+
+// String implements the [flag.Value] and [fmt.Stringer] interfaces.
+// It returns an empty string if value is missing.
+func (c *Custom[T, V]) String() string {
+	if !c.hasValue {
+		return ""
+	}
+
+	text, err := textcodec.Marshal(c.value)
+	if err != nil {
+		return fmt.Sprint(c.value)
+	}
+
+	return string(text)
+}
+
+// IsBoolFlag reports whether the underlying type is boolean,
+// so that the flag is set without value, e.g. "-verbose" instead of "-verbose=true".
+func (c *Custom[T, V]) IsBoolFlag() bool {
+	return reflect.TypeFor[T]().Kind() == reflect.Bool
+}
@@ -0,0 +1,45 @@
+package required
+
+import (
+	"github.com/vmihailenco/msgpack/v5"
+	"github.com/vmihailenco/msgpack/v5/msgpcode"
+
+	"github.com/metafates/schema/internal/msgpackcodec"
+	"github.com/metafates/schema/validate"
+)
+
+var _ interface {
+	msgpack.CustomDecoder
+	msgpack.CustomEncoder
+} = (*Custom[any, validate.Validator[any]])(nil)
+
+// DecodeMsgpack implements the [msgpack.CustomDecoder] interface.
+// Nil results in a missing value, which does not pass validation.
+// Value is decoded into T directly, so that its integer and float widths are preserved.
+func (c *Custom[T, V]) DecodeMsgpack(dec *msgpack.Decoder) error {
+	code, err := dec.PeekCode()
+	if err != nil {
+		return err
+	}
+
+	// validated status will reset here
+	if code == msgpcode.Nil {
+		*c = Custom[T, V]{}
+
+		return dec.DecodeNil()
+	}
+
+	value, err := msgpackcodec.Decode[T](dec)
+	if err != nil {
+		return err
+	}
+
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
+}
+
+// EncodeMsgpack implements the [msgpack.CustomEncoder] interface.
+func (c Custom[T, V]) EncodeMsgpack(enc *msgpack.Encoder) error {
+	return enc.Encode(c.Get())
+}
@@ -3,6 +3,7 @@ package required
 import (
 	"encoding"
 
+	"github.com/metafates/schema/internal/textcodec"
 	"github.com/metafates/schema/validate"
 )
 
@@ -12,11 +13,24 @@ var _ interface {
 } = (*Custom[any, validate.Validator[any]])(nil)
 
 // UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+//
+// Text is decoded with [encoding.TextUnmarshaler] of T, if implemented.
+// Otherwise strings and byte slices are set from raw text, [time.Duration] is parsed with [time.ParseDuration],
+// other basic types are parsed with strconv (e.g. [strconv.ParseInt]) and anything else is decoded as json.
 func (c *Custom[T, V]) UnmarshalText(data []byte) error {
-	return c.UnmarshalJSON(data)
+	value, err := textcodec.Unmarshal[T](data)
+	if err != nil {
+		return err
+	}
+
+	// validated status will reset here
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
 }
 
 // MarshalText implements the [encoding.TextMarshaler] interface.
+// It is the reverse of [Custom.UnmarshalText].
 func (c Custom[T, V]) MarshalText() ([]byte, error) {
-	return c.MarshalJSON()
+	return textcodec.Marshal(c.Get())
 }
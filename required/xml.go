@@ -0,0 +1,51 @@
+package required
+
+import (
+	"encoding/xml"
+
+	"github.com/metafates/schema/internal/textcodec"
+	"github.com/metafates/schema/validate"
+)
+
+var _ interface {
+	xml.Unmarshaler
+	xml.Marshaler
+	xml.UnmarshalerAttr
+	xml.MarshalerAttr
+} = (*Custom[any, validate.Validator[any]])(nil)
+
+// UnmarshalXML implements the [xml.Unmarshaler] interface.
+func (c *Custom[T, V]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value T
+
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+
+	// validated status will reset here
+	*c = Custom[T, V]{value: value, hasValue: true}
+
+	return nil
+}
+
+// MarshalXML implements the [xml.Marshaler] interface.
+func (c Custom[T, V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(c.Get(), start)
+}
+
+// UnmarshalXMLAttr implements the [xml.UnmarshalerAttr] interface.
+// Attribute value is decoded as text, see [Custom.UnmarshalText].
+func (c *Custom[T, V]) UnmarshalXMLAttr(attr xml.Attr) error {
+	return c.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalXMLAttr implements the [xml.MarshalerAttr] interface.
+// Attribute value is encoded as text, see [Custom.MarshalText].
+func (c Custom[T, V]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	text, err := textcodec.Marshal(c.Get())
+	if err != nil {
+		return xml.Attr{}, err
+	}
+
+	return xml.Attr{Name: name, Value: string(text)}, nil
+}
@@ -0,0 +1,28 @@
+package transform
+
+import (
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+func (Trim[T]) Transform(value T) T  { return T(strings.TrimSpace(string(value))) }
+func (Lower[T]) Transform(value T) T { return T(strings.ToLower(string(value))) }
+func (Upper[T]) Transform(value T) T { return T(strings.ToUpper(string(value))) }
+func (NFC[T]) Transform(value T) T   { return T(norm.NFC.String(string(value))) }
+
+func (CollapseSpace[T]) Transform(value T) T {
+	return T(strings.Join(strings.Fields(string(value)), " "))
+}
+
+func (And[T, A, B]) Transform(value T) T {
+	return (*new(B)).Transform((*new(A)).Transform(value))
+}
+
+func (Pipe[T, X, V]) Transform(value T) T {
+	return (*new(X)).Transform(value)
+}
+
+func (Pipe[T, X, V]) Validate(value T) error {
+	return (*new(V)).Validate(value)
+}
@@ -0,0 +1,64 @@
+// Package transform provides transformers which normalize values before validation.
+//
+// Transformers are combined with validators using [Pipe]:
+//
+//	type Email = required.Custom[string, transform.Pipe[string, transform.TrimLower[string], validate.Email[string]]]
+package transform
+
+import (
+	"github.com/metafates/schema/constraint"
+	"github.com/metafates/schema/validate"
+)
+
+// Transformer is an interface that transformers must implement.
+// Like [validate.Validator], it's a special empty (struct{}) type that is invoked in a form of (*new(X)).Transform(...).
+// Therefore it should not depend on inner state (fields).
+type Transformer[T any] interface {
+	Transform(value T) T
+}
+
+type (
+	// Trim removes leading and trailing white space.
+	Trim[T constraint.Text] struct{}
+
+	// Lower maps all Unicode letters to their lower case.
+	Lower[T constraint.Text] struct{}
+
+	// Upper maps all Unicode letters to their upper case.
+	Upper[T constraint.Text] struct{}
+
+	// NFC applies Unicode canonical composition (Normalization Form C).
+	NFC[T constraint.Text] struct{}
+
+	// CollapseSpace replaces each sequence of white space with a single space
+	// and removes leading and trailing white space.
+	CollapseSpace[T constraint.Text] struct{}
+
+	// And is a meta transformer that applies transformers in the same order as type parameters.
+	And[T any, A Transformer[T], B Transformer[T]] struct{}
+
+	// Pipe transforms value with X and then validates the result with V.
+	//
+	// It implements both [Transformer] and [validate.Validator],
+	// required and optional types apply the transformer before validation.
+	Pipe[T any, X Transformer[T], V validate.Validator[T]] struct{}
+)
+
+// Common aliases.
+type (
+	// TrimLower combines [Trim] and [Lower].
+	TrimLower[T constraint.Text] = And[T, Trim[T], Lower[T]]
+
+	// TrimUpper combines [Trim] and [Upper].
+	TrimUpper[T constraint.Text] = And[T, Trim[T], Upper[T]]
+)
+
+// Apply transforms the value if V implements [Transformer].
+// Otherwise, the value is returned as is.
+func Apply[T, V any](value T) T {
+	if t, ok := any(*new(V)).(Transformer[T]); ok {
+		return t.Transform(value)
+	}
+
+	return value
+}
@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"testing"
+
+	"github.com/metafates/schema/internal/testutil"
+	"github.com/metafates/schema/validate"
+)
+
+func TestTransformer(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		transformer Transformer[string]
+		value, want string
+	}{
+		{
+			name:        "trim",
+			transformer: Trim[string]{},
+			value:       " \t john \n",
+			want:        "john",
+		},
+		{
+			name:        "lower",
+			transformer: Lower[string]{},
+			value:       "John@Example.COM",
+			want:        "john@example.com",
+		},
+		{
+			name:        "upper",
+			transformer: Upper[string]{},
+			value:       "usd",
+			want:        "USD",
+		},
+		{
+			name:        "nfc",
+			transformer: NFC[string]{},
+			value:       "e\u0301",
+			want:        "\u00e9",
+		},
+		{
+			name:        "collapse space",
+			transformer: CollapseSpace[string]{},
+			value:       "  john \t\n doe ",
+			want:        "john doe",
+		},
+		{
+			name:        "and",
+			transformer: TrimLower[string]{},
+			value:       " John@Example.COM ",
+			want:        "john@example.com",
+		},
+		{
+			name:        "pipe",
+			transformer: Pipe[string, Trim[string], validate.Email[string]]{},
+			value:       " john@example.com ",
+			want:        "john@example.com",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testutil.Equal(t, tc.want, tc.transformer.Transform(tc.value))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	testutil.Equal(t, "john", Apply[string, Trim[string]](" john "))
+	testutil.Equal(t, " john ", Apply[string, validate.NonZero[string]](" john "))
+}
+
+func TestPipe_Validate(t *testing.T) {
+	var pipe Pipe[string, TrimLower[string], validate.Email[string]]
+
+	testutil.NoError(t, pipe.Validate(pipe.Transform(" John@Example.COM ")))
+	testutil.Error(t, pipe.Validate(pipe.Transform(" not an email ")))
+}
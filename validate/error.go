@@ -92,3 +92,35 @@ func (e ValidationError) error() string {
 	// path: msg: inner error
 	return strings.Join(segments, ": ")
 }
+
+// PathError is an error which occurred at some value, e.g. [ValidationError] or parse.ParseError.
+type PathError interface {
+	error
+
+	// Path returns the path to the value which raised this error, e.g. ".Friends[0].Name".
+	Path() string
+}
+
+// Errors is a list of errors returned by [ValidateAll] and parse.Parse with parse.WithAllErrors option.
+// It may contain both validation and parse errors.
+type Errors []PathError
+
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+func (e Errors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
@@ -83,93 +83,130 @@ func (Odd[T]) Validate(value T) error {
 }
 
 func (Email[T]) Validate(value T) error {
-	_, err := mail.ParseAddress(string(value))
-	if err != nil {
-		return err
-	}
+	_, err := Email[T]{}.Refine(value)
 
-	return nil
+	return err
+}
+
+func (Email[T]) Refine(value T) (*mail.Address, error) {
+	return mail.ParseAddress(string(value))
 }
 
 func (URL[T]) Validate(value T) error {
-	_, err := url.Parse(string(value))
-	if err != nil {
-		return err
-	}
+	_, err := URL[T]{}.Refine(value)
 
-	return nil
+	return err
+}
+
+func (URL[T]) Refine(value T) (*url.URL, error) {
+	return url.Parse(string(value))
 }
 
 func (HTTPURL[T]) Validate(value T) error {
+	_, err := HTTPURL[T]{}.Refine(value)
+
+	return err
+}
+
+func (HTTPURL[T]) Refine(value T) (*url.URL, error) {
 	u, err := url.Parse(string(value))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if u.Host == "" {
-		return errors.New("empty host")
+		return nil, errors.New("empty host")
 	}
 
 	switch u.Scheme {
 	case "http", "https":
-		return nil
+		return u, nil
 
 	default:
-		return errors.New("non-http(s) scheme")
+		return nil, errors.New("non-http(s) scheme")
 	}
 }
 
 func (IP[T]) Validate(value T) error {
-	_, err := netip.ParseAddr(string(value))
-	if err != nil {
-		return err
-	}
+	_, err := IP[T]{}.Refine(value)
 
-	return nil
+	return err
+}
+
+func (IP[T]) Refine(value T) (netip.Addr, error) {
+	return netip.ParseAddr(string(value))
 }
 
 func (IPV4[T]) Validate(value T) error {
+	_, err := IPV4[T]{}.Refine(value)
+
+	return err
+}
+
+func (IPV4[T]) Refine(value T) (netip.Addr, error) {
 	a, err := netip.ParseAddr(string(value))
 	if err != nil {
-		return err
+		return netip.Addr{}, err
 	}
 
 	if !a.Is4() {
-		return errors.New("ipv6 address")
+		return netip.Addr{}, errors.New("ipv6 address")
 	}
 
-	return nil
+	return a, nil
 }
 
 func (IPV6[T]) Validate(value T) error {
+	_, err := IPV6[T]{}.Refine(value)
+
+	return err
+}
+
+func (IPV6[T]) Refine(value T) (netip.Addr, error) {
 	a, err := netip.ParseAddr(string(value))
 	if err != nil {
-		return err
+		return netip.Addr{}, err
 	}
 
 	if !a.Is6() {
-		return errors.New("ipv6 address")
+		return netip.Addr{}, errors.New("ipv6 address")
 	}
 
-	return nil
+	return a, nil
 }
 
 func (MAC[T]) Validate(value T) error {
-	_, err := net.ParseMAC(string(value))
-	if err != nil {
-		return err
-	}
+	_, err := MAC[T]{}.Refine(value)
 
-	return nil
+	return err
+}
+
+func (MAC[T]) Refine(value T) (net.HardwareAddr, error) {
+	return net.ParseMAC(string(value))
 }
 
 func (CIDR[T]) Validate(value T) error {
-	_, _, err := net.ParseCIDR(string(value))
+	_, err := CIDR[T]{}.Refine(value)
+
+	return err
+}
+
+func (CIDR[T]) Refine(value T) (netip.Prefix, error) {
+	// net.ParseCIDR is used instead of netip.ParsePrefix to keep accepting the same inputs,
+	// e.g. prefix length with leading zeros
+	ip, network, err := net.ParseCIDR(string(value))
 	if err != nil {
-		return err
+		return netip.Prefix{}, err
 	}
 
-	return nil
+	addr, _ := netip.AddrFromSlice(ip)
+	if len(network.Mask) == net.IPv4len {
+		addr = addr.Unmap()
+	}
+
+	bits, _ := network.Mask.Size()
+
+	return netip.PrefixFrom(addr, bits), nil
 }
 
 func (Base64[T]) Validate(value T) error {
@@ -261,12 +298,18 @@ func (NonEmpty[S, T]) Validate(value S) error {
 }
 
 func (MIME[T]) Validate(value T) error {
-	_, _, err := mime.ParseMediaType(string(value))
+	_, err := MIME[T]{}.Refine(value)
+
+	return err
+}
+
+func (MIME[T]) Refine(value T) (MediaType, error) {
+	mediatype, params, err := mime.ParseMediaType(string(value))
 	if err != nil {
-		return err
+		return MediaType{}, err
 	}
 
-	return nil
+	return MediaType{Type: mediatype, Params: params}, nil
 }
 
 func (UUID[T]) Validate(value T) error {
@@ -278,6 +321,10 @@ func (UUID[T]) Validate(value T) error {
 	return nil
 }
 
+func (UUID[T]) Refine(value T) ([16]byte, error) {
+	return uuid.Parse(string(value))
+}
+
 func (JSON[T]) Validate(value T) error {
 	if !json.Valid([]byte(string(value))) {
 		return errors.New("invalid json")
@@ -15,6 +15,19 @@ type (
 		Validate(value T) error
 	}
 
+	// Refiner is a validator that produces a refined (parsed) value of type R during validation.
+	// For example, [URL] refines text into *url.URL.
+	//
+	// Refine is called only for values which passed Validate,
+	// so it does not have to repeat the checks of Validate.
+	//
+	// See required.Refined and optional.Refined types.
+	Refiner[T, R any] interface {
+		Validator[T]
+
+		Refine(value T) (R, error)
+	}
+
 	// TypeValidateable is an interface for types that can validate their types.
 	// This is used by required and optional fields so that they can validate if contained values
 	// satisfy the schema enforced by [Validator] backed type.
@@ -35,6 +48,15 @@ type (
 	}
 )
 
+// MediaType is a media type refined by [MIME] validator.
+type MediaType struct {
+	// Type is a lower-cased media type, e.g. "text/html".
+	Type string
+
+	// Params are media type parameters, e.g. charset.
+	Params map[string]string
+}
+
 // Validate checks if the provided value can be validated and reports any validation errors.
 //
 // The validation process follows these steps:
@@ -46,44 +68,83 @@ type (
 //
 // A [ValidationError] is returned if any validation fails during any step.
 //
+// Map values are not addressable, so they are validated as copies which are stored back into the map
+// if validation modifies them, e.g. marks them as validated or applies defaults and transforms.
+// Validating such map concurrently is a data race, while maps of already validated values are only read.
+//
 // If v is nil or not a pointer, Validate returns an [InvalidValidateError].
 func Validate(v any) error {
+	return validateValue(v, false)
+}
+
+// ValidateAll is like [Validate], but it does not stop at the first error.
+// Instead, all errors are returned in [Errors].
+//
+// Values nested in the invalid ones are not reported, neither [Validateable] values are
+// validated if there are type validation errors.
+func ValidateAll(v any) error {
+	return validateValue(v, true)
+}
+
+func validateValue(v any, all bool) error {
+	var err error
+
 	switch v := v.(type) {
 	case interface {
 		TypeValidateable
 		Validateable
 	}:
-		if err := v.TypeValidate(); err != nil {
-			return ValidationError{Inner: err}
-		}
-
-		if err := v.Validate(); err != nil {
-			return ValidationError{Inner: err}
+		if err = v.TypeValidate(); err == nil {
+			err = v.Validate()
 		}
 
-		return nil
-
 	case TypeValidateable:
-		if err := v.TypeValidate(); err != nil {
-			return ValidationError{Inner: err}
+		err = v.TypeValidate()
+
+	default:
+		// same thing [json.Unmarshal] does
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Pointer || rv.IsNil() {
+			return &InvalidValidateError{Type: reflect.TypeOf(v)}
 		}
 
+		return validate(v, all)
+	}
+
+	if err == nil {
 		return nil
 	}
 
-	// same thing [json.Unmarshal] does
-	rv := reflect.ValueOf(v)
-	if rv.Kind() != reflect.Pointer || rv.IsNil() {
-		return &InvalidValidateError{Type: reflect.TypeOf(v)}
+	if all {
+		return Errors{ValidationError{Inner: err}}
 	}
 
-	return validate(v)
+	return ValidationError{Inner: err}
 }
 
-func validate(v any) error {
-	var postValidate []func() error
+// pathValidateable is a [Validateable] value at the given path.
+type pathValidateable struct {
+	path  string
+	value Validateable
+}
+
+func validate(v any, all bool) error {
+	var (
+		postValidate []pathValidateable
+		errs         Errors
+
+		// walk paths of the values which failed type validation
+		failed []string
+	)
 
 	err := reflectwalk.WalkFields(v, func(path string, reflectValue reflect.Value) error {
+		// values nested in the invalid ones are reported by them
+		for _, f := range failed {
+			if reflectwalk.IsNested(path, f) {
+				return nil
+			}
+		}
+
 		if reflectValue.CanAddr() {
 			reflectValue = reflectValue.Addr()
 		}
@@ -92,18 +153,19 @@ func validate(v any) error {
 
 		if value, ok := value.(TypeValidateable); ok {
 			if err := value.TypeValidate(); err != nil {
-				return ValidationError{Inner: err, path: path}
-			}
-		}
-
-		if value, ok := value.(Validateable); ok {
-			postValidate = append(postValidate, func() error {
-				if err := value.Validate(); err != nil {
+				if !all {
 					return ValidationError{Inner: err, path: path}
 				}
 
+				errs = append(errs, ValidationError{Inner: err, path: path})
+				failed = append(failed, path)
+
 				return nil
-			})
+			}
+		}
+
+		if value, ok := value.(Validateable); ok {
+			postValidate = append(postValidate, pathValidateable{path: path, value: value})
 		}
 
 		return nil
@@ -112,11 +174,23 @@ func validate(v any) error {
 		return err
 	}
 
-	for _, f := range postValidate {
-		if err := f(); err != nil {
-			return err
+	if len(errs) > 0 {
+		return errs
+	}
+
+	for _, v := range postValidate {
+		if err := v.value.Validate(); err != nil {
+			if !all {
+				return ValidationError{Inner: err, path: v.path}
+			}
+
+			errs = append(errs, ValidationError{Inner: err, path: v.path})
 		}
 	}
 
+	if len(errs) > 0 {
+		return errs
+	}
+
 	return nil
 }
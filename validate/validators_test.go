@@ -1,7 +1,11 @@
 package validate_test
 
 import (
+	"encoding/json"
+	"errors"
+	"net/netip"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
@@ -229,6 +233,10 @@ var suites = []Testable{
 			Name:  "valid cidr",
 			Input: "192.0.2.0/24",
 		},
+		{
+			Name:  "prefix length with leading zero",
+			Input: "192.0.2.0/024",
+		},
 		{
 			Name:    "invalid cidr",
 			Input:   "192.0.2.0@24",
@@ -643,4 +651,142 @@ func TestValidate(t *testing.T) {
 			testutil.Error(t, schemajson.Unmarshal(data, &users))
 		})
 	})
+
+	t.Run("map", func(t *testing.T) {
+		var users map[string]User
+
+		data := []byte(`{"foo": {"name": "foo"}, "bar": {"name": "bar"}}`)
+
+		testutil.NoError(t, schemajson.Unmarshal(data, &users))
+		testutil.Equal(t, "foo", users["foo"].Name.Get())
+
+		// validated map is not modified, so it can be validated concurrently (see go test -race)
+		var wg sync.WaitGroup
+
+		for range 4 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				shared := users
+
+				testutil.NoError(t, Validate(&shared))
+			}()
+		}
+
+		wg.Wait()
+	})
+}
+
+func TestValidateAll(t *testing.T) {
+	type Item struct {
+		Name required.NonZero[string] `json:"name"`
+	}
+
+	type Order struct {
+		ID    required.NonZero[string] `json:"id"`
+		Items []Item                   `json:"items"`
+		Tags  map[string]Item          `json:"tags"`
+	}
+
+	var order Order
+
+	data := []byte(`{"items": [{"name": "foo"}, {}], "tags": {"a": {}}}`)
+
+	testutil.NoError(t, json.Unmarshal(data, &order))
+	testutil.Error(t, Validate(&order))
+
+	err := ValidateAll(&order)
+
+	var errs Errors
+
+	testutil.Equal(t, true, errors.As(err, &errs))
+
+	paths := make([]string, 0, len(errs))
+
+	for _, e := range errs {
+		paths = append(paths, e.Path())
+	}
+
+	testutil.DeepEqual(t, []string{".ID", ".Items[1].Name", ".Tags[a].Name"}, paths)
+
+	order.ID.MustParse("1")
+	order.Items = order.Items[:1]
+	order.Tags = nil
+
+	testutil.NoError(t, ValidateAll(&order))
+}
+
+func TestRefiner(t *testing.T) {
+	t.Run("url", func(t *testing.T) {
+		u, err := HTTPURL[string]{}.Refine("https://example.com/path?q=1")
+
+		testutil.NoError(t, err)
+		testutil.Equal(t, "example.com", u.Host)
+		testutil.Equal(t, "/path", u.Path)
+
+		_, err = HTTPURL[string]{}.Refine("ftp://example.com")
+		testutil.Error(t, err)
+	})
+
+	t.Run("email", func(t *testing.T) {
+		addr, err := Email[string]{}.Refine("John Doe <john@example.com>")
+
+		testutil.NoError(t, err)
+		testutil.Equal(t, "John Doe", addr.Name)
+		testutil.Equal(t, "john@example.com", addr.Address)
+	})
+
+	t.Run("ip", func(t *testing.T) {
+		addr, err := IPV4[string]{}.Refine("192.0.2.1")
+
+		testutil.NoError(t, err)
+		testutil.Equal(t, netip.MustParseAddr("192.0.2.1"), addr)
+
+		_, err = IPV4[string]{}.Refine("2001:db8::68")
+		testutil.Error(t, err)
+	})
+
+	t.Run("cidr", func(t *testing.T) {
+		prefix, err := CIDR[string]{}.Refine("192.0.2.0/24")
+
+		testutil.NoError(t, err)
+		testutil.Equal(t, 24, prefix.Bits())
+
+		// accepted by validation, so must be accepted by refinement too
+		prefix, err = CIDR[string]{}.Refine("192.0.2.1/024")
+
+		testutil.NoError(t, err)
+		testutil.Equal(t, netip.MustParsePrefix("192.0.2.1/24"), prefix)
+
+		prefix, err = CIDR[string]{}.Refine("::ffff:192.0.2.1/120")
+
+		testutil.NoError(t, err)
+		testutil.Equal(t, netip.MustParsePrefix("::ffff:192.0.2.1/120"), prefix)
+	})
+
+	t.Run("mime", func(t *testing.T) {
+		mediaType, err := MIME[string]{}.Refine("Text/HTML; charset=utf-8")
+
+		testutil.NoError(t, err)
+		testutil.Equal(t, "text/html", mediaType.Type)
+		testutil.Equal(t, "utf-8", mediaType.Params["charset"])
+	})
+
+	t.Run("uuid", func(t *testing.T) {
+		want := [16]byte{0x2c, 0x37, 0x6d, 0x16, 0x32, 0x1d, 0x43, 0xb3, 0x86, 0x48, 0x2e, 0x64, 0x79, 0x8c, 0xc6, 0xb3}
+
+		for _, input := range []string{
+			"2c376d16-321d-43b3-8648-2e64798cc6b3",
+			"urn:uuid:2c376d16-321d-43b3-8648-2e64798cc6b3",
+			"{2c376d16-321d-43b3-8648-2e64798cc6b3}",
+			"2c376d16321d43b386482e64798cc6b3",
+		} {
+			id, err := UUID[string]{}.Refine(input)
+
+			testutil.NoError(t, err)
+			testutil.Equal(t, want, id)
+		}
+	})
 }